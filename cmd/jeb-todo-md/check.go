@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// runCheck walks the link graph from the todo file and prints one line per
+// problem. It exits 1 when any problem is found, so it can gate commits.
+func runCheck(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("check", "Report broken links, cycles, chains too deep to navigate, and markdown files\nunder the vault root that the todo file cannot reach.", &filePath, &configPath)
+	skipOrphans := flags.Bool("no-orphans", false, "Do not report unreachable markdown files")
+	flags.Parse(args)
+
+	filePath, err := todoFilePath(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return commandError(err)
+	}
+
+	vaultRoot := config.VaultRootFor(filePath)
+	issues, err := tui.CheckLinks(filePath, vaultRoot, tui.NewLinkResolver(vaultRoot))
+	if err != nil {
+		return commandError(err)
+	}
+
+	problems := 0
+	for _, issue := range issues {
+		if *skipOrphans && issue.Kind == tui.IssueOrphan {
+			continue
+		}
+		problems++
+		location := tui.RelativeDisplayPath(vaultRoot, issue.Path)
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, issue.Line)
+		}
+		fmt.Printf("%s: %s: %s\n", location, issue.Kind, issue.Message)
+	}
+	if problems > 0 {
+		fmt.Fprintf(os.Stderr, "%d problem(s) found\n", problems)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// command is a subcommand run instead of the TUI. run receives the arguments
+// after the command name plus the -f and --config values given before it,
+// and returns the process exit status.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, filePath, configPath string) int
+}
+
+// commands lists the subcommands in the order shown by --help.
+var commands = []command{
+	{"add", "Append a todo: add TEXT..., or add - to read lines from stdin", runAdd},
+	{"list", "List todos with their indexes", runList},
+	{"done", "Check todos: done INDEX...", runDone},
+	{"undone", "Uncheck todos: undone INDEX...", runUndone},
+	{"rm", "Delete todos: rm INDEX...", runRm},
+	{"edit", "Replace a todo's text: edit INDEX TEXT...", runEdit},
+	{"sort", "Sort each section's todos by priority, then due date", runSort},
+	{"query", "Search todos across linked files: query TERM...", runQuery},
+	{"agenda", "List open todos with due dates across linked files", runAgenda},
+	{"tags", "Count #tags, @contexts, and +projects across linked files", runTags},
+	{"check", "Validate links from the todo file: broken, cyclic, too deep, orphaned", runCheck},
+	{"graph", "Print the link graph as Graphviz DOT or Mermaid (--format)", runGraph},
+}
+
+// runCommand dispatches to the named subcommand.
+func runCommand(name string, args []string, filePath, configPath string) int {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args, filePath, configPath)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Error: unknown command: %s\n", name)
+	flag.Usage()
+	return 1
+}
+
+// printCommandUsage lists the subcommands for the top-level usage message.
+func printCommandUsage() {
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// newCommandFlags returns a flag set for a subcommand that also accepts
+// -f/--file and --config after the command name, defaulting to the values
+// given before it.
+func newCommandFlags(name, usage string, filePath, configPath *string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.StringVar(filePath, "file", *filePath, "Path to markdown todo file (overrides JEB_TODO_FILE)")
+	flags.StringVar(filePath, "f", *filePath, "Path to markdown todo file (shorthand)")
+	flags.StringVar(configPath, "config", *configPath, "Path to JSON config file")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s [OPTIONS]\n\n%s\n\nOptions:\n", os.Args[0], name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
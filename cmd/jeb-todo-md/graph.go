@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// runGraph prints the link graph reachable from the todo file as DOT or Mermaid.
+func runGraph(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("graph", "Print the files reachable from the todo file and the links between them\nas a Graphviz DOT or Mermaid diagram.", &filePath, &configPath)
+	format := flags.String("format", tui.GraphFormatDOT, "Output format: dot or mermaid")
+	flags.Parse(args)
+
+	filePath, err := todoFilePath(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return commandError(err)
+	}
+
+	vaultRoot := config.VaultRootFor(filePath)
+	graph := tui.BuildLinkGraph(filePath, tui.NewLinkResolver(vaultRoot))
+	if err := graph.Root().Err; err != nil {
+		return commandError(err)
+	}
+	output, err := tui.FormatGraph(graph, vaultRoot, *format)
+	if err != nil {
+		return commandError(err)
+	}
+	fmt.Print(output)
+	return 0
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -20,19 +21,26 @@ func main() {
 	var filePath string
 	var showVersion bool
 	var returnPaths string
+	var clipboardMode string
+	var configPath string
 
 	flag.StringVar(&filePath, "file", "", "Path to markdown todo file (overrides JEB_TODO_FILE)")
 	flag.StringVar(&filePath, "f", "", "Path to markdown todo file (shorthand)")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 	flag.BoolVar(&showVersion, "v", false, "Show version information (shorthand)")
 	flag.StringVar(&returnPaths, "return", "", "Comma-separated file paths for back-navigation stack")
+	flag.StringVar(&clipboardMode, "clipboard", "", "Also copy yanked todos to the clipboard: none, system, or osc52 (overrides config)")
+	flag.StringVar(&configPath, "config", "", "Path to JSON config file (default $XDG_CONFIG_HOME/jeb-todo-md/config.json)")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [OPTIONS] [COMMAND]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "A minimal TUI for editing markdown todo files.\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
-		fmt.Fprintf(os.Stderr, "\nIf -f/--file is not provided, reads from JEB_TODO_FILE environment variable.\n")
+		fmt.Fprintf(os.Stderr, "\nCommands:\n")
+		printCommandUsage()
+		fmt.Fprintf(os.Stderr, "\nWithout a command, opens the TUI.\n")
+		fmt.Fprintf(os.Stderr, "If -f/--file is not provided, reads from JEB_TODO_FILE environment variable.\n")
 	}
 
 	flag.Parse()
@@ -42,20 +50,13 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Precedence: --file flag > JEB_TODO_FILE env var
-	if filePath == "" {
-		filePath = os.Getenv("JEB_TODO_FILE")
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(flag.Arg(0), flag.Args()[1:], filePath, configPath))
 	}
 
-	if filePath == "" {
-		fmt.Fprintln(os.Stderr, "Error: no todo file specified")
-		fmt.Fprintln(os.Stderr, "  Set JEB_TODO_FILE environment variable, or use -f/--file flag")
-		os.Exit(1)
-	}
-
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: file not found: %s\n", filePath)
-		os.Exit(1)
+	filePath, err := todoFilePath(filePath)
+	if err != nil {
+		exitWithError(err)
 	}
 
 	// Parse return stack paths
@@ -74,8 +75,58 @@ func main() {
 		}
 	}
 
-	if err := tui.Run(filePath, returnStack); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	config, err := loadConfig(configPath)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	// Precedence: --clipboard flag > config file
+	if clipboardMode != "" {
+		config.Clipboard = clipboardMode
+		if err := config.Validate(); err != nil {
+			exitWithError(err)
+		}
+	}
+
+	if err := tui.Run(filePath, returnStack, config); err != nil {
+		exitWithError(err)
 	}
 }
+
+// todoFilePath applies the --file > JEB_TODO_FILE precedence and checks
+// that the resulting file exists.
+func todoFilePath(filePath string) (string, error) {
+	if filePath == "" {
+		filePath = os.Getenv("JEB_TODO_FILE")
+	}
+	if filePath == "" {
+		return "", errors.New("no todo file specified\n  Set JEB_TODO_FILE environment variable, or use -f/--file flag")
+	}
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return "", fmt.Errorf("file not found: %s", filePath)
+	}
+	return filePath, nil
+}
+
+// loadConfig loads the config at configPath, or at the default location
+// when configPath is empty.
+func loadConfig(configPath string) (tui.Config, error) {
+	if configPath == "" {
+		defaultConfigPath, err := tui.ConfigPath()
+		if err != nil {
+			return tui.Config{}, fmt.Errorf("locating config: %w", err)
+		}
+		configPath = defaultConfigPath
+	}
+	config, err := tui.LoadConfig(configPath)
+	if err != nil {
+		return tui.Config{}, fmt.Errorf("loading config: %w", err)
+	}
+	return config, nil
+}
+
+// exitWithError prints err and exits with status 1.
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
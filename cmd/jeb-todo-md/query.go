@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// queryUsage documents the filter language shared by query and agenda.
+const queryUsage = `Terms (all must match, case-insensitive; quote to group words):
+  status:open|done|all   checkbox state
+  tag:name               has #name (or @name, +name when written so)
+  due-before:DATE        due date before DATE (YYYY-MM-DD, today, tomorrow,
+                         +3d, +2w, +1m, or a weekday)
+  file:GLOB              file path relative to the vault root, or its base name
+  section:text           nearest heading contains text
+  anything else          text contains it`
+
+// runQuery prints the todos matching a query across every file reachable
+// from the todo file.
+func runQuery(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("query", "Search todos in every file reachable through links.\n\n  query TERM...\n\n"+queryUsage, &filePath, &configPath)
+	asJSON := flags.Bool("json", false, "Print matches as a JSON array, like list --json")
+	flags.Parse(args)
+
+	results, vaultRoot, resolver, code := queryGraph(strings.Join(flags.Args(), " "), filePath, configPath)
+	if code != 0 {
+		return code
+	}
+	if *asJSON {
+		return printResultsJSON(results, resolver)
+	}
+	for _, result := range results {
+		fmt.Println(formatResultLine(vaultRoot, result))
+	}
+	return 0
+}
+
+// runAgenda prints open todos with due dates, earliest first, across every
+// file reachable from the todo file. Extra terms narrow it like query.
+func runAgenda(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("agenda", "List open todos with a due:YYYY-MM-DD or 📅 YYYY-MM-DD date, earliest first,\nacross every file reachable through links.\n\n  agenda [TERM...]\n\n"+queryUsage, &filePath, &configPath)
+	asJSON := flags.Bool("json", false, "Print the agenda as a JSON array, like list --json")
+	flags.Parse(args)
+
+	source := strings.Join(append([]string{"status:open"}, flags.Args()...), " ")
+	results, vaultRoot, resolver, code := queryGraph(source, filePath, configPath)
+	if code != 0 {
+		return code
+	}
+	results = tui.Agenda(results)
+	if *asJSON {
+		return printResultsJSON(results, resolver)
+	}
+	for _, result := range results {
+		due, _ := result.Item.DueDate()
+		fmt.Printf("%s  %s\n", due.Format("2006-01-02"), formatResultLine(vaultRoot, result))
+	}
+	return 0
+}
+
+// queryGraph runs source against the link graph of the todo file. A
+// non-zero code means an error was already reported.
+func queryGraph(source, filePath, configPath string) ([]tui.QueryResult, string, *tui.LinkResolver, int) {
+	query, err := tui.ParseQuery(source)
+	if err != nil {
+		return nil, "", nil, commandError(err)
+	}
+	filePath, err = todoFilePath(filePath)
+	if err != nil {
+		return nil, "", nil, commandError(err)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return nil, "", nil, commandError(err)
+	}
+
+	vaultRoot := config.VaultRootFor(filePath)
+	resolver := tui.NewLinkResolver(vaultRoot)
+	graph := tui.BuildLinkGraph(filePath, resolver)
+	if err := graph.Root().Err; err != nil {
+		return nil, "", nil, commandError(err)
+	}
+	return tui.RunQuery(graph, vaultRoot, query), vaultRoot, resolver, 0
+}
+
+// formatResultLine renders a match as "file:line [x] text".
+func formatResultLine(vaultRoot string, result tui.QueryResult) string {
+	check := " "
+	if result.Item.Checked {
+		check = "x"
+	}
+	return fmt.Sprintf("%s:%d [%s] %s", tui.RelativeDisplayPath(vaultRoot, result.Path), result.Line, check, result.Item.Text)
+}
+
+// printResultsJSON prints results in the list --json format.
+func printResultsJSON(results []tui.QueryResult, resolver *tui.LinkResolver) int {
+	entries := make([]listEntry, len(results))
+	for i, result := range results {
+		entries[i] = resultEntry(result, resolver)
+	}
+	return printJSON(entries)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// tagEntry is one label in tags --json output.
+type tagEntry struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// runTags prints how many todos carry each #tag, @context, and +project
+// across every file reachable from the todo file.
+func runTags(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("tags", "Count the todos carrying each #tag, @context, and +project in every file\nreachable through links, most used first. Query terms narrow the todos counted.\n\n  tags [TERM...]\n\n"+queryUsage, &filePath, &configPath)
+	asJSON := flags.Bool("json", false, "Print a JSON array of labels and counts")
+	flags.Parse(args)
+
+	results, _, _, code := queryGraph(strings.Join(flags.Args(), " "), filePath, configPath)
+	if code != 0 {
+		return code
+	}
+	items := make([]tui.TodoItem, len(results))
+	for i, result := range results {
+		items[i] = result.Item
+	}
+	counts := tui.CountLabels(items)
+
+	if *asJSON {
+		entries := make([]tagEntry, len(counts))
+		for i, count := range counts {
+			entries[i] = tagEntry{Label: count.Label, Count: count.Count}
+		}
+		return printJSON(entries)
+	}
+	width := 0
+	if len(counts) > 0 {
+		width = len(fmt.Sprint(counts[0].Count))
+	}
+	for _, count := range counts {
+		fmt.Printf("%*d  %s\n", width, count.Count, count.Label)
+	}
+	return 0
+}
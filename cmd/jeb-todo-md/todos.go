@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// runAdd appends a todo built from the remaining arguments, or with "-"
+// appends one todo per line of stdin.
+func runAdd(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("add", "Append a todo to the end of the file.\n\n  add TEXT...\n  add -    read one todo per line from stdin: plain text, \"- [ ]\" lines, or todo.txt", &filePath, &configPath)
+	flags.Parse(args)
+
+	var items []tui.TodoItem
+	if flags.NArg() == 1 && flags.Arg(0) == "-" {
+		stdinItems, err := readInputItems(os.Stdin)
+		if err != nil {
+			return commandError(fmt.Errorf("reading stdin: %w", err))
+		}
+		if len(stdinItems) == 0 {
+			return commandError(errors.New("no todos on stdin"))
+		}
+		items = stdinItems
+	} else {
+		text := strings.TrimSpace(strings.Join(flags.Args(), " "))
+		if text == "" {
+			return usageError(flags.Usage, "no todo text given")
+		}
+		items = []tui.TodoItem{{Text: text}}
+	}
+
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return commandError(err)
+	}
+	todoFile, err := openTodoFile(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	todoFile.Options = config.FileOptions()
+	now := time.Now()
+	for _, item := range items {
+		todoFile.InsertTodo(-1, todoFile.StampCreated(item, now))
+	}
+	if err := todoFile.Save(); err != nil {
+		return commandError(fmt.Errorf("saving: %w", err))
+	}
+	for i := todoFile.TodoCount() - len(items); i < todoFile.TodoCount(); i++ {
+		fmt.Println("Added " + formatListLine(i, todoFile.GetTodo(i)))
+	}
+	return 0
+}
+
+// readInputItems parses one todo per non-blank line of r.
+func readInputItems(r io.Reader) ([]tui.TodoItem, error) {
+	var items []tui.TodoItem
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if item, ok := tui.ParseInputLine(scanner.Text()); ok {
+			items = append(items, item)
+		}
+	}
+	return items, scanner.Err()
+}
+
+// runList prints every todo with its 1-based index, or as JSON with --json.
+// With --recursive it also lists every file reachable through links.
+func runList(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("list", "Print the todos with the indexes other commands take.", &filePath, &configPath)
+	asJSON := flags.Bool("json", false, "Print a JSON array of todos with line, link, and section details")
+	recursive := flags.Bool("recursive", false, "Also list todos in every file reachable through links")
+	flags.Parse(args)
+
+	filePath, err := todoFilePath(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return commandError(err)
+	}
+	vaultRoot := config.VaultRootFor(filePath)
+	resolver := tui.NewLinkResolver(vaultRoot)
+
+	var files []*tui.TodoFile
+	if *recursive {
+		graph := tui.BuildLinkGraph(filePath, resolver)
+		if err := graph.Root().Err; err != nil {
+			return commandError(err)
+		}
+		for _, node := range graph.Nodes {
+			if node.File != nil {
+				files = append(files, node.File)
+			}
+		}
+	} else {
+		todoFile, err := tui.ParseFile(filePath)
+		if err != nil {
+			return commandError(err)
+		}
+		files = append(files, todoFile)
+	}
+
+	if *asJSON {
+		entries := []listEntry{}
+		for _, todoFile := range files {
+			entries = append(entries, listEntries(todoFile, resolver)...)
+		}
+		return printJSON(entries)
+	}
+	for i, todoFile := range files {
+		if *recursive {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(tui.RelativeDisplayPath(vaultRoot, todoFile.Path))
+		}
+		for todoIdx := 0; todoIdx < todoFile.TodoCount(); todoIdx++ {
+			fmt.Println(formatListLine(todoIdx, todoFile.GetTodo(todoIdx)))
+		}
+	}
+	return 0
+}
+
+// listEntry is one todo in list --json output.
+type listEntry struct {
+	// File is the absolute path of the file containing the todo.
+	File string `json:"file"`
+	// Index is the 1-based index other commands take.
+	Index int `json:"index"`
+	// Line is the 1-based line number in File.
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	// Link is the absolute path the todo links to, if it is a linked todo.
+	Link string `json:"link,omitempty"`
+	// Section is the nearest heading above the todo.
+	Section string `json:"section,omitempty"`
+	// Completed is when the todo was checked, from its completion marker, as
+	// YYYY-MM-DD or YYYY-MM-DDTHH:MM.
+	Completed string `json:"completed,omitempty"`
+	// Created is the date from the todo's created marker, as YYYY-MM-DD.
+	Created string `json:"created,omitempty"`
+}
+
+// listEntries builds the list --json entries for every todo in todoFile.
+func listEntries(todoFile *tui.TodoFile, resolver *tui.LinkResolver) []listEntry {
+	absolutePath, err := filepath.Abs(todoFile.Path)
+	if err != nil {
+		absolutePath = todoFile.Path
+	}
+	entries := make([]listEntry, todoFile.TodoCount())
+	for i := range entries {
+		entries[i] = resultEntry(tui.QueryResult{
+			Path:      absolutePath,
+			TodoIndex: i,
+			Line:      todoFile.TodoIndices[i] + 1,
+			Section:   todoFile.Section(i),
+			Item:      todoFile.GetTodo(i),
+		}, resolver)
+	}
+	return entries
+}
+
+// resultEntry converts a located todo to its JSON form.
+func resultEntry(result tui.QueryResult, resolver *tui.LinkResolver) listEntry {
+	entry := listEntry{
+		File:    result.Path,
+		Index:   result.TodoIndex + 1,
+		Line:    result.Line,
+		Text:    result.Item.Text,
+		Checked: result.Item.Checked,
+		Link:    resolver.Resolve(result.Path, result.Item),
+		Section: result.Section,
+	}
+	if completed, ok := result.Item.CompletedAt(); ok {
+		entry.Completed = completed.Format("2006-01-02")
+		if !completed.Equal(tui.StartOfDay(completed)) {
+			entry.Completed = completed.Format("2006-01-02T15:04")
+		}
+	}
+	if created, ok := result.Item.CreatedAt(); ok {
+		entry.Created = created.Format("2006-01-02")
+	}
+	return entry
+}
+
+// printJSON writes value to stdout as indented JSON.
+func printJSON(value any) int {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return commandError(err)
+	}
+	return 0
+}
+
+// runDone checks the todos at the given indexes.
+func runDone(args []string, filePath, configPath string) int {
+	return setChecked("done", "Check the todos at the given indexes.\n\n  done INDEX...", true, args, filePath, configPath)
+}
+
+// runUndone unchecks the todos at the given indexes.
+func runUndone(args []string, filePath, configPath string) int {
+	return setChecked("undone", "Uncheck the todos at the given indexes.\n\n  undone INDEX...", false, args, filePath, configPath)
+}
+
+// setChecked implements done and undone. Todos already in the requested
+// state are left alone. Completing a repeating todo adds its next copy,
+// which is printed after the checked todos.
+func setChecked(name, usage string, checked bool, args []string, filePath, configPath string) int {
+	flags := newCommandFlags(name, usage, &filePath, &configPath)
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return usageError(flags.Usage, "no index given")
+	}
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return commandError(err)
+	}
+	todoFile, err := openTodoFile(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	todoFile.Options = config.FileOptions()
+	indexes, err := parseIndexes(flags.Args(), todoFile.TodoCount())
+	if err != nil {
+		return commandError(err)
+	}
+
+	// Work from the bottom up so inserted copies don't shift the indexes
+	// still to come.
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+	var added []int
+	for i := len(indexes) - 1; i >= 0; i-- {
+		if todoFile.GetTodo(indexes[i]).Checked == checked {
+			continue
+		}
+		nextIdx := todoFile.ToggleTodo(indexes[i])
+		if nextIdx < 0 {
+			continue
+		}
+		// Everything at or below the inserted copy moves down one.
+		for k := i; k < len(indexes); k++ {
+			if indexes[k] >= nextIdx {
+				indexes[k]++
+			}
+		}
+		for j := range added {
+			added[j]++
+		}
+		added = append(added, nextIdx)
+	}
+	if err := todoFile.Save(); err != nil {
+		return commandError(fmt.Errorf("saving: %w", err))
+	}
+	for _, todoIdx := range indexes {
+		fmt.Println(formatListLine(todoIdx, todoFile.GetTodo(todoIdx)))
+	}
+	slices.Sort(added)
+	for _, todoIdx := range added {
+		fmt.Println("Added " + formatListLine(todoIdx, todoFile.GetTodo(todoIdx)))
+	}
+	return 0
+}
+
+// runRm deletes the todos at the given indexes.
+func runRm(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("rm", "Delete the todos at the given indexes.\n\n  rm INDEX...", &filePath, &configPath)
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return usageError(flags.Usage, "no index given")
+	}
+	todoFile, err := openTodoFile(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	indexes, err := parseIndexes(flags.Args(), todoFile.TodoCount())
+	if err != nil {
+		return commandError(err)
+	}
+
+	// Delete from the bottom up so earlier indexes stay valid.
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+	removed := make([]string, len(indexes))
+	for i := len(indexes) - 1; i >= 0; i-- {
+		removed[i] = formatListLine(indexes[i], todoFile.GetTodo(indexes[i]))
+		todoFile.DeleteTodo(indexes[i])
+	}
+	if err := todoFile.Save(); err != nil {
+		return commandError(fmt.Errorf("saving: %w", err))
+	}
+	for _, line := range removed {
+		fmt.Println("Removed " + line)
+	}
+	return 0
+}
+
+// runEdit replaces the text of one todo, keeping its checkbox.
+func runEdit(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("edit", "Replace the text of the todo at INDEX.\n\n  edit INDEX TEXT...", &filePath, &configPath)
+	flags.Parse(args)
+
+	if flags.NArg() < 2 {
+		return usageError(flags.Usage, "edit takes an index and the new text")
+	}
+	text := strings.TrimSpace(strings.Join(flags.Args()[1:], " "))
+	if text == "" {
+		return usageError(flags.Usage, "no todo text given")
+	}
+	todoFile, err := openTodoFile(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	indexes, err := parseIndexes(flags.Args()[:1], todoFile.TodoCount())
+	if err != nil {
+		return commandError(err)
+	}
+
+	todoFile.SetTodoText(indexes[0], text)
+	if err := todoFile.Save(); err != nil {
+		return commandError(fmt.Errorf("saving: %w", err))
+	}
+	fmt.Println(formatListLine(indexes[0], todoFile.GetTodo(indexes[0])))
+	return 0
+}
+
+// runSort sorts the todos under each heading by priority, then due date,
+// and prints the result.
+func runSort(args []string, filePath, configPath string) int {
+	flags := newCommandFlags("sort", "Sort the top-level todos under each heading by priority ((A), !!!, ⏫\nfirst), then by due date. Subtasks move with their parent; headings and\nother lines stay in place.", &filePath, &configPath)
+	flags.Parse(args)
+
+	if flags.NArg() > 0 {
+		return usageError(flags.Usage, "sort takes no arguments")
+	}
+	todoFile, err := openTodoFile(filePath)
+	if err != nil {
+		return commandError(err)
+	}
+	todoFile.SortBySection()
+	if err := todoFile.Save(); err != nil {
+		return commandError(fmt.Errorf("saving: %w", err))
+	}
+	for todoIdx := 0; todoIdx < todoFile.TodoCount(); todoIdx++ {
+		fmt.Println(formatListLine(todoIdx, todoFile.GetTodo(todoIdx)))
+	}
+	return 0
+}
+
+// openTodoFile resolves the todo file path and parses it.
+func openTodoFile(filePath string) (*tui.TodoFile, error) {
+	filePath, err := todoFilePath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return tui.ParseFile(filePath)
+}
+
+// parseIndexes converts 1-based index arguments to logical todo indexes,
+// rejecting anything outside 1..count.
+func parseIndexes(args []string, count int) ([]int, error) {
+	indexes := make([]int, len(args))
+	for i, arg := range args {
+		index, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index: %s", arg)
+		}
+		if index < 1 || index > count {
+			return nil, fmt.Errorf("index %d out of range (file has %d todos)", index, count)
+		}
+		indexes[i] = index - 1
+	}
+	return indexes, nil
+}
+
+// formatListLine renders a todo as "INDEX [x] text" with a 1-based index.
+func formatListLine(todoIdx int, item tui.TodoItem) string {
+	check := " "
+	if item.Checked {
+		check = "x"
+	}
+	return fmt.Sprintf("%d [%s] %s", todoIdx+1, check, item.Text)
+}
+
+// commandError prints err and returns the failing exit status.
+func commandError(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return 1
+}
+
+// usageError prints message followed by the command's usage.
+func usageError(usage func(), message string) int {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", message)
+	usage()
+	return 1
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIndexes(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []int
+		errText string
+	}{
+		{"first and last", []string{"1", "5"}, []int{0, 4}, ""},
+		{"keeps order and duplicates", []string{"3", "1", "3"}, []int{2, 0, 2}, ""},
+		{"zero", []string{"0"}, nil, "index 0 out of range (file has 5 todos)"},
+		{"past the end", []string{"2", "6"}, nil, "index 6 out of range (file has 5 todos)"},
+		{"negative", []string{"-1"}, nil, "index -1 out of range"},
+		{"not a number", []string{"two"}, nil, "invalid index: two"},
+		{"range syntax", []string{"1-3"}, nil, "invalid index: 1-3"},
+	}
+	for _, tt := range tests {
+		got, err := parseIndexes(tt.args, 5)
+		if tt.errText != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.errText, err)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("%s: parseIndexes(%v) = %v, %v; want %v", tt.name, tt.args, got, err, tt.want)
+		}
+	}
+}
+
+// runOnFile writes content to a temp todo file, runs the command on it with
+// default settings, and returns its exit status, output, and the new file.
+func runOnFile(t *testing.T, run func(args []string, filePath, configPath string) int, content string, args ...string) (int, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "todo.md")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	status := run(args, filePath, filepath.Join(dir, "config.json"))
+	os.Stdout = stdout
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return status, string(output), string(data)
+}
+
+func TestRunDone_ShiftsIndexesAfterRecurrence(t *testing.T) {
+	content := "- [ ] Trash every:week due:2026-10-20\n- [ ] Dishes\n- [ ] Laundry every:3d due:2026-10-18\n- [ ] Mail\n"
+	status, output, file := runOnFile(t, runDone, content, "4", "1", "3", "1")
+	if status != 0 {
+		t.Fatalf("exit status %d, output %q", status, output)
+	}
+	stamp := " ✅ " + time.Now().Format("2006-01-02")
+	wantFile := "- [x] Trash every:week due:2026-10-20" + stamp + "\n" +
+		"- [ ] Trash every:week due:2026-10-27\n" +
+		"- [ ] Dishes\n" +
+		"- [x] Laundry every:3d due:2026-10-18" + stamp + "\n" +
+		"- [ ] Laundry every:3d due:2026-10-21\n" +
+		"- [x] Mail\n"
+	if file != wantFile {
+		t.Errorf("file =\n%s\nwant\n%s", file, wantFile)
+	}
+	wantOutput := "1 [x] Trash every:week due:2026-10-20" + stamp + "\n" +
+		"4 [x] Laundry every:3d due:2026-10-18" + stamp + "\n" +
+		"6 [x] Mail\n" +
+		"Added 2 [ ] Trash every:week due:2026-10-27\n" +
+		"Added 5 [ ] Laundry every:3d due:2026-10-21\n"
+	if output != wantOutput {
+		t.Errorf("output =\n%s\nwant\n%s", output, wantOutput)
+	}
+}
+
+func TestRunDone_OutOfRangeLeavesFile(t *testing.T) {
+	content := "- [ ] One\n- [ ] Two\n"
+	status, _, file := runOnFile(t, runDone, content, "1", "3")
+	if status == 0 || file != content {
+		t.Errorf("exit status %d, file %q; want a failure and the file unchanged", status, file)
+	}
+}
+
+func TestRunRm(t *testing.T) {
+	content := "# List\n- [ ] One\n- [ ] Two\n- [ ] Three\n- [ ] Four\n"
+	tests := []struct {
+		name   string
+		args   []string
+		file   string
+		output string
+	}{
+		{"several", []string{"4", "2"}, "# List\n- [ ] One\n- [ ] Three\n", "Removed 2 [ ] Two\nRemoved 4 [ ] Four\n"},
+		{"duplicates", []string{"1", "1", "3"}, "# List\n- [ ] Two\n- [ ] Four\n", "Removed 1 [ ] One\nRemoved 3 [ ] Three\n"},
+		{"all", []string{"1", "2", "3", "4"}, "# List\n", "Removed 1 [ ] One\nRemoved 2 [ ] Two\nRemoved 3 [ ] Three\nRemoved 4 [ ] Four\n"},
+	}
+	for _, tt := range tests {
+		status, output, file := runOnFile(t, runRm, content, tt.args...)
+		if status != 0 || file != tt.file || output != tt.output {
+			t.Errorf("%s: rm %v = status %d, file %q, output %q; want file %q, output %q", tt.name, tt.args, status, file, output, tt.file, tt.output)
+		}
+	}
+
+	if status, _, file := runOnFile(t, runRm, content, "2", "5"); status == 0 || file != content {
+		t.Errorf("rm with an out-of-range index: status %d, file %q; want a failure and the file unchanged", status, file)
+	}
+}
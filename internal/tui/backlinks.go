@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Backlink is a todo in another file that links to a given file.
+type Backlink struct {
+	// Path is the file containing the linking todo.
+	Path string
+	// TodoIndex is the linking todo's logical index in Path.
+	TodoIndex int
+	Item      TodoItem
+}
+
+// FindBacklinks scans every markdown file under root for todos whose link,
+// resolved with resolver, points at targetPath. Links from targetPath to
+// itself are ignored. Results are ordered by file, then position.
+func FindBacklinks(root, targetPath string, resolver *LinkResolver) ([]Backlink, error) {
+	targetAbsolutePath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ListMarkdownFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var backlinks []Backlink
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if absolutePath, err := filepath.Abs(path); err != nil || absolutePath == targetAbsolutePath {
+			continue
+		}
+		todoFile, err := ParseFile(path)
+		if err != nil {
+			continue
+		}
+		for i := 0; i < todoFile.TodoCount(); i++ {
+			item := todoFile.GetTodo(i)
+			resolvedPath := resolver.Resolve(path, item)
+			if resolvedPath == "" {
+				continue
+			}
+			if absolutePath, err := filepath.Abs(resolvedPath); err == nil && absolutePath == targetAbsolutePath {
+				backlinks = append(backlinks, Backlink{Path: path, TodoIndex: i, Item: item})
+			}
+		}
+	}
+	return backlinks, nil
+}
+
+// maxBacklinkAncestors caps how many directories above the root file's
+// directory a backlink search climbs when no vault root is configured.
+const maxBacklinkAncestors = 3
+
+// backlinkSearchRoots returns the directories searched for backlinks, in
+// order: the configured vault root alone, or the root file's directory
+// followed by up to maxBacklinkAncestors of its parents, stopping at the
+// home directory. A file opened with -f is often linked from a parent
+// directory, which the root file's directory alone would miss.
+func (m model) backlinkSearchRoots() []string {
+	root := m.resolver.VaultRoot
+	if m.config.VaultRoot != "" {
+		return []string{root}
+	}
+	homeDir, _ := os.UserHomeDir()
+	roots := []string{root}
+	for dir := absPath(root); len(roots) <= maxBacklinkAncestors && dir != homeDir; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		roots = append(roots, parent)
+		dir = parent
+	}
+	return roots
+}
+
+// backlinksFoundMsg is returned by findBacklinksCmd with the files linking to
+// target and the directory they were found under.
+type backlinksFoundMsg struct {
+	target    string
+	root      string
+	backlinks []Backlink
+	err       error
+}
+
+// findBacklinksCmd returns a tea.Cmd that searches each of roots in turn for
+// links to target, stopping at the first that has any.
+func findBacklinksCmd(roots []string, target string, resolver *LinkResolver) tea.Cmd {
+	return func() tea.Msg {
+		msg := backlinksFoundMsg{target: target}
+		for _, root := range roots {
+			msg.root = root
+			msg.backlinks, msg.err = FindBacklinks(root, target, resolver)
+			if msg.err != nil || len(msg.backlinks) > 0 {
+				break
+			}
+		}
+		return msg
+	}
+}
+
+// handleBacklinksFound opens the backlinks list for the current file.
+func (m model) handleBacklinksFound(msg backlinksFoundMsg) (tea.Model, tea.Cmd) {
+	if msg.target != m.file.Path {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.backlinks) == 0 {
+		m.noticeMessage = "No files link here"
+		return m, nil
+	}
+	m.backlinks = msg.backlinks
+	m.backlinksRoot = msg.root
+	m.backlinksCursor = 0
+	m.mode = ModeBacklinks
+	return m, nil
+}
+
+func (m model) updateBacklinks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.backlinksCursor < len(m.backlinks)-1 {
+			m.backlinksCursor++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.backlinksCursor > 0 {
+			m.backlinksCursor--
+		}
+	case key.Matches(msg, m.keys.Confirm):
+		m.mode = ModeNormal
+		return m.openBacklink(m.backlinks[m.backlinksCursor])
+	case key.Matches(msg, m.keys.Backlinks), key.Matches(msg, m.keys.Cancel):
+		m.mode = ModeNormal
+	}
+	return m, nil
+}
+
+// openBacklink opens the parent file with the cursor on the linking item.
+// The current file is pushed so going back returns here.
+func (m model) openBacklink(backlink Backlink) (tea.Model, tea.Cmd) {
+	if len(m.navStack) >= maxNavStackDepth {
+		m.statusMessage = fmt.Sprintf("Error: maximum navigation depth (%d) reached", maxNavStackDepth)
+		return m, nil
+	}
+	stack := append(append([]navigationEntry(nil), m.navStack...), navigationEntry{
+		FilePath:       m.file.Path,
+		CursorPosition: m.cursor,
+	})
+	return m, jumpToFileCmd(backlink.Path, backlink.TodoIndex, stack)
+}
+
+// renderBacklinks lists the linking items as "file: item".
+func (m model) renderBacklinks() string {
+	var b strings.Builder
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  Linked from %d place(s):", len(m.backlinks))))
+	b.WriteString("\n\n")
+
+	visibleRows := len(m.backlinks)
+	if m.height > 0 {
+		visibleRows = min(visibleRows, max(1, m.height-m.listTop()-5))
+	}
+	start := max(0, m.backlinksCursor-visibleRows+1)
+	end := min(len(m.backlinks), start+visibleRows)
+
+	for i := start; i < end; i++ {
+		backlink := m.backlinks[i]
+		source := RelativeDisplayPath(m.backlinksRoot, backlink.Path)
+		if i == m.backlinksCursor {
+			b.WriteString(cursorStyle.Render(" > "+source+": ") + linkStyle.Render(backlink.Item.DisplayText()))
+		} else {
+			b.WriteString("   " + source + ": " + backlink.Item.DisplayText())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
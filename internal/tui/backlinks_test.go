@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBacklinks_SearchParentDirectoryWithoutVaultRoot(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, "todo.md")
+	if err := os.WriteFile(parent, []byte("- [ ] Plain\n- [ ] todo:work/tasks.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModelAt(t, filepath.Join(dir, "work", "tasks.md"), "- [ ] Ship it\n", DefaultConfig())
+
+	m = press(m, "B")
+	if m.mode != ModeBacklinks {
+		t.Fatalf("mode = %v, want backlinks (notice %q)", m.mode, m.noticeMessage)
+	}
+	if len(m.backlinks) != 1 || m.backlinks[0].Path != parent || m.backlinks[0].TodoIndex != 1 {
+		t.Errorf("backlinks = %+v, want todo.md item 1", m.backlinks)
+	}
+	if got := RelativeDisplayPath(m.backlinksRoot, m.backlinks[0].Path); got != "todo.md" {
+		t.Errorf("source shown as %q, want todo.md", got)
+	}
+}
+
+func TestBacklinks_ConfiguredVaultRootIsNotWidened(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "todo.md"), []byte("- [ ] todo:work/tasks.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.VaultRoot = filepath.Join(dir, "work")
+	m := newTestModelAt(t, filepath.Join(dir, "work", "tasks.md"), "- [ ] Ship it\n", config)
+
+	m = press(m, "B")
+	if m.mode == ModeBacklinks || m.noticeMessage != "No files link here" {
+		t.Errorf("expected no backlinks inside the vault root, got %+v", m.backlinks)
+	}
+}
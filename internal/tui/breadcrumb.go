@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	breadcrumbSeparator = " › "
+	breadcrumbEllipsis  = "…"
+	// maxAncestorJump is the highest ancestor number reachable with a single digit.
+	maxAncestorJump = 9
+)
+
+// breadcrumbs returns the basenames of every file on the navigation stack
+// followed by the current file. When numbered is set, ancestors that can be
+// jumped to are prefixed with their number.
+func (m model) breadcrumbs(numbered bool) []string {
+	crumbs := make([]string, 0, len(m.navStack)+1)
+	for i, entry := range m.navStack {
+		crumb := fileBasenameWithoutExtension(entry.FilePath)
+		if numbered && i < maxAncestorJump {
+			crumb = fmt.Sprintf("%d %s", i+1, crumb)
+		}
+		crumbs = append(crumbs, crumb)
+	}
+	return append(crumbs, fileBasenameWithoutExtension(m.file.Path))
+}
+
+// fitBreadcrumbs joins crumbs, collapsing middle crumbs into an ellipsis
+// until the trail fits in width. The root and current file are kept unless
+// even they do not fit. A width of zero or less means unlimited.
+func fitBreadcrumbs(crumbs []string, width int) string {
+	trail := strings.Join(crumbs, breadcrumbSeparator)
+	if width <= 0 || lipgloss.Width(trail) <= width || len(crumbs) <= 2 {
+		return trail
+	}
+
+	// Drop crumbs after the root, oldest first, keeping as many recent ones as fit.
+	for dropped := 1; dropped < len(crumbs)-1; dropped++ {
+		kept := append([]string{crumbs[0], breadcrumbEllipsis}, crumbs[1+dropped:]...)
+		trail = strings.Join(kept, breadcrumbSeparator)
+		if lipgloss.Width(trail) <= width {
+			return trail
+		}
+	}
+	return breadcrumbEllipsis + breadcrumbSeparator + crumbs[len(crumbs)-1]
+}
+
+// renderHeader builds the header with the breadcrumb trail and date.
+func (m model) renderHeader() string {
+	currentDateFormatted := m.today().Format("Jan 2, 2006")
+	prefix := m.headerIcon + " "
+	suffix := fmt.Sprintf(" [%s]", currentDateFormatted)
+
+	available := 0
+	if m.width > 0 {
+		available = max(1, m.width-lipgloss.Width(prefix)-lipgloss.Width(suffix))
+	}
+	trail := fitBreadcrumbs(m.breadcrumbs(m.pendingAncestorJump), available)
+	return titleStyle.Render(prefix + trail + suffix)
+}
+
+// updateAncestorJump handles the key pressed after the breadcrumb key: a
+// digit pops the navigation stack back to that ancestor (1 is the root),
+// anything else cancels.
+func (m model) updateAncestorJump(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.pendingAncestorJump = false
+
+	ancestor, err := strconv.Atoi(msg.String())
+	if err != nil || ancestor < 1 || ancestor > min(len(m.navStack), maxAncestorJump) {
+		return m, nil
+	}
+
+	entry := m.navStack[ancestor-1]
+	m.navStack = m.navStack[:ancestor-1]
+	return m, loadFileCmd(entry.FilePath, entry.CursorPosition)
+}
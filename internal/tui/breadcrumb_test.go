@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFitBreadcrumbs(t *testing.T) {
+	crumbs := []string{"root", "a", "b", "c"}
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{"unlimited", 0, "root › a › b › c"},
+		{"fits", 16, "root › a › b › c"},
+		{"drops oldest after root", 15, "root › … › c"},
+		{"only current fits", 11, "… › c"},
+	}
+	for _, tt := range tests {
+		if got := fitBreadcrumbs(crumbs, tt.width); got != tt.want {
+			t.Errorf("%s: fitBreadcrumbs(width %d) = %q, want %q", tt.name, tt.width, got, tt.want)
+		}
+	}
+
+	if got := fitBreadcrumbs([]string{"root", "current"}, 3); got != "root › current" {
+		t.Errorf("root and current alone should never collapse, got %q", got)
+	}
+	if got := fitBreadcrumbs([]string{"root", "aaaa", "b", "c"}, 16); got != "root › … › b › c" {
+		t.Errorf("expected only the oldest middle crumb dropped, got %q", got)
+	}
+}
+
+func TestUpdateAncestorJump(t *testing.T) {
+	m := newTestModel(t, "- [ ] Plain\n- [ ] todo:a.md\n", DefaultConfig())
+	root := m.file.Path
+	writeLinkedFile(t, m, "a.md", "- [ ] todo:b.md\n")
+	writeLinkedFile(t, m, "b.md", "- [ ] Leaf\n")
+
+	m = press(m, "j", "enter", "enter")
+	if got := filepath.Base(m.file.Path); got != "b.md" || len(m.navStack) != 2 {
+		t.Fatalf("expected b.md two levels deep, got %s with %d ancestors", got, len(m.navStack))
+	}
+
+	m = press(m, "b", "3")
+	if filepath.Base(m.file.Path) != "b.md" || m.pendingAncestorJump {
+		t.Errorf("out-of-range digit should cancel in place, got %s", m.file.Path)
+	}
+
+	m = press(m, "b", "2")
+	if got := filepath.Base(m.file.Path); got != "a.md" || len(m.navStack) != 1 {
+		t.Fatalf("expected a.md one level deep, got %s with %d ancestors", got, len(m.navStack))
+	}
+
+	m = press(m, "b", "1")
+	if m.file.Path != root {
+		t.Fatalf("expected to jump to the root, got %s", m.file.Path)
+	}
+	if len(m.navStack) != 0 {
+		t.Errorf("expected an empty stack at the root, got %d entries", len(m.navStack))
+	}
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want the link it was on (1)", m.cursor)
+	}
+}
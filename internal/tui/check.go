@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IssueKind classifies a problem found by CheckLinks.
+type IssueKind string
+
+const (
+	// IssueBrokenLink is a link whose target cannot be read.
+	IssueBrokenLink IssueKind = "broken"
+	// IssueCycle is a link leading back to a file above it, including itself.
+	IssueCycle IssueKind = "cycle"
+	// IssueTooDeep is a file more links below the root than the TUI can follow.
+	IssueTooDeep IssueKind = "too-deep"
+	// IssueOrphan is a markdown file under the vault root not reachable from the root file.
+	IssueOrphan IssueKind = "orphan"
+)
+
+// Issue is one problem in the link graph.
+type Issue struct {
+	Kind IssueKind
+	// Path is the absolute path of the file the issue is reported in.
+	Path string
+	// Line is the 1-based line of the offending link, or 0 for a whole file.
+	Line int
+	// Message describes the problem, with paths relative to the vault root.
+	Message string
+}
+
+// CheckLinks walks links from rootPath and reports broken links, cycles,
+// files deeper than the TUI's navigation limit, and markdown files under
+// vaultRoot that cannot be reached from rootPath. Orphans are skipped when
+// vaultRoot is empty. An error is returned only if the root file or vault
+// cannot be read.
+func CheckLinks(rootPath, vaultRoot string, resolver *LinkResolver) ([]Issue, error) {
+	graph := BuildLinkGraph(rootPath, resolver)
+	if err := graph.Root().Err; err != nil {
+		return nil, err
+	}
+	display := func(path string) string { return RelativeDisplayPath(vaultRoot, path) }
+
+	var issues []Issue
+	for _, edge := range graph.Edges {
+		target := graph.Node(edge.To)
+		if target.Err == nil {
+			continue
+		}
+		message := fmt.Sprintf("link to %s: %v", display(edge.To), target.Err)
+		if os.IsNotExist(target.Err) {
+			message = fmt.Sprintf("link to %s: file not found", display(edge.To))
+		}
+		issues = append(issues, graph.edgeIssue(IssueBrokenLink, edge, message))
+	}
+
+	for _, cycle := range graph.cycles() {
+		edge := cycle[len(cycle)-1]
+		var message string
+		if edge.From == edge.To {
+			message = "links to itself"
+		} else {
+			names := []string{display(cycle[0].From)}
+			for _, step := range cycle {
+				names = append(names, display(step.To))
+			}
+			message = strings.Join(names, " → ")
+		}
+		issues = append(issues, graph.edgeIssue(IssueCycle, edge, message))
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Depth == maxNavStackDepth+1 {
+			issues = append(issues, Issue{
+				Kind:    IssueTooDeep,
+				Path:    node.Path,
+				Message: fmt.Sprintf("%d links below %s; navigation stops at %d", node.Depth, display(graph.Root().Path), maxNavStackDepth),
+			})
+		}
+	}
+
+	if vaultRoot == "" {
+		return issues, nil
+	}
+	files, err := ListMarkdownFiles(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		path := filepath.Join(vaultRoot, filepath.FromSlash(file))
+		if graph.Node(path) == nil {
+			issues = append(issues, Issue{
+				Kind:    IssueOrphan,
+				Path:    absPath(path),
+				Message: fmt.Sprintf("not linked from %s", display(graph.Root().Path)),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// edgeIssue builds an issue located at the line of edge's linking todo.
+func (g *LinkGraph) edgeIssue(kind IssueKind, edge GraphEdge, message string) Issue {
+	source := g.Node(edge.From)
+	return Issue{
+		Kind:    kind,
+		Path:    edge.From,
+		Line:    source.File.TodoIndices[edge.TodoIndex] + 1,
+		Message: message,
+	}
+}
+
+// cycles returns one edge path per link that leads back to a file on the
+// current depth-first path from the root. Each path starts at the file the
+// cycle returns to and ends with the closing link.
+func (g *LinkGraph) cycles() [][]GraphEdge {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := map[string]int{}
+	var path []GraphEdge
+	var cycles [][]GraphEdge
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = onPath
+		for _, edge := range g.OutEdges(node) {
+			switch state[edge.To] {
+			case onPath:
+				start := len(path)
+				for start > 0 && path[start-1].To != edge.To {
+					start--
+				}
+				if edge.From == edge.To {
+					start = len(path)
+				}
+				cycle := append(append([]GraphEdge(nil), path[start:]...), edge)
+				cycles = append(cycles, cycle)
+			case unvisited:
+				path = append(path, edge)
+				visit(edge.To)
+				path = path[:len(path)-1]
+			}
+		}
+		state[node] = done
+	}
+	visit(g.Root().Path)
+	return cycles
+}
+
+// RelativeDisplayPath returns path relative to root with forward slashes,
+// or path unchanged when root is empty or path is not beneath it.
+func RelativeDisplayPath(root, path string) string {
+	if root == "" {
+		return path
+	}
+	relativePath, err := filepath.Rel(absPath(root), absPath(path))
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return path
+	}
+	return filepath.ToSlash(relativePath)
+}
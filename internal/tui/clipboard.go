@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// osc52Hold is how long an OSC 52 sequence stays in the view, long enough
+// for the renderer to draw at least one frame with it.
+const osc52Hold = 250 * time.Millisecond
+
+// clipboardErrorMsg reports a failed copy to the system clipboard.
+type clipboardErrorMsg struct {
+	err error
+}
+
+// osc52Msg carries an OSC 52 copy sequence for the view to emit. Writing it
+// from a command would race the renderer for stdout.
+type osc52Msg struct {
+	sequence string
+}
+
+// osc52ClearMsg removes sequence from the view once it has been drawn.
+type osc52ClearMsg struct {
+	sequence string
+}
+
+// formatRegister renders yanked todos as markdown checkbox lines.
+func formatRegister(items []TodoItem) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = FormatTodoLine(item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// copyToClipboardCmd copies text to the clipboard selected by mode.
+// Returns nil when mode disables clipboard output.
+func copyToClipboardCmd(mode, text string) tea.Cmd {
+	switch mode {
+	case ClipboardSystem:
+		return func() tea.Msg {
+			if err := clipboard.WriteAll(text); err != nil {
+				return clipboardErrorMsg{err: fmt.Errorf("copying to clipboard: %w", err)}
+			}
+			return nil
+		}
+	case ClipboardOSC52:
+		sequence := osc52.New(text)
+		if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+			sequence = sequence.Screen()
+		}
+		return func() tea.Msg {
+			return osc52Msg{sequence: sequence.String()}
+		}
+	}
+	return nil
+}
+
+// handleOSC52 puts an OSC 52 sequence at the start of the view so the
+// renderer writes it with the next frame, then clears it.
+func (m model) handleOSC52(msg osc52Msg) (tea.Model, tea.Cmd) {
+	m.osc52 = msg.sequence
+	return m, tea.Tick(osc52Hold, func(time.Time) tea.Msg {
+		return osc52ClearMsg{sequence: msg.sequence}
+	})
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestCopyToClipboardCmd_OSC52GoesThroughView(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	config := DefaultConfig()
+	config.Clipboard = ClipboardOSC52
+	m := newTestModel(t, "- [ ] one\n", config)
+
+	msg, ok := copyToClipboardCmd(ClipboardOSC52, "- [ ] one")().(osc52Msg)
+	if !ok {
+		t.Fatal("expected the OSC 52 command to return an osc52Msg")
+	}
+	next, _ := m.Update(msg)
+	m = next.(model)
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("- [ ] one")) + "\a"
+	if !strings.HasPrefix(m.View(), want) {
+		t.Errorf("view does not start with the OSC 52 sequence %q", want)
+	}
+
+	next, _ = m.Update(osc52ClearMsg{sequence: "stale"})
+	if !strings.HasPrefix(next.(model).View(), want) {
+		t.Error("a stale clear removed the current sequence")
+	}
+	next, _ = m.Update(osc52ClearMsg{sequence: msg.sequence})
+	if strings.Contains(next.(model).View(), "\x1b]52") {
+		t.Error("the sequence stayed in the view after it was cleared")
+	}
+}
+
+func TestYank_OSC52ClearsAfterDrawing(t *testing.T) {
+	config := DefaultConfig()
+	config.Clipboard = ClipboardOSC52
+	m := newTestModel(t, "- [ ] one\n", config)
+
+	m = press(m, "y")
+	if len(m.register) != 1 || m.osc52 != "" {
+		t.Errorf("after yank: register %v, pending sequence %q", m.register, m.osc52)
+	}
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// doneTimeLayout is the timestamp format used in done: tokens.
+const doneTimeLayout = "2006-01-02T15:04"
+
+var (
+	// completionRegex matches a "✅ YYYY-MM-DD" date or a done:YYYY-MM-DD
+	// token with an optional THH:MM time, with the whitespace before it.
+	completionRegex = regexp.MustCompile(`(?:^|\s+)(?:✅\s*(\d{4}-\d{2}-\d{2})|done:(\d{4}-\d{2}-\d{2}(?:T\d{2}:\d{2})?))\b`)
+	// createdRegex matches a "➕ YYYY-MM-DD" date or a created:YYYY-MM-DD
+	// token, with the whitespace before it.
+	createdRegex = regexp.MustCompile(`(?:^|\s+)(?:➕\s*|created:)(\d{4}-\d{2}-\d{2})\b`)
+)
+
+// CompletedAt returns when the todo was checked, from a "✅ YYYY-MM-DD" date
+// or a done: token, in local time. The bool is false when there is none.
+func (item TodoItem) CompletedAt() (time.Time, bool) {
+	match := completionRegex.FindStringSubmatch(item.Text)
+	if match == nil {
+		return time.Time{}, false
+	}
+	if match[1] != "" {
+		return parseLocal(dueDateLayout, match[1])
+	}
+	if len(match[2]) == len(dueDateLayout) {
+		return parseLocal(dueDateLayout, match[2])
+	}
+	return parseLocal(doneTimeLayout, match[2])
+}
+
+// CreatedAt returns the date from a "➕ YYYY-MM-DD" date or a
+// created:YYYY-MM-DD token, in local time. The bool is false when there is
+// none.
+func (item TodoItem) CreatedAt() (time.Time, bool) {
+	match := createdRegex.FindStringSubmatch(item.Text)
+	if match == nil {
+		return time.Time{}, false
+	}
+	return parseLocal(dueDateLayout, match[1])
+}
+
+// parseLocal parses value with layout in local time.
+func parseLocal(layout, value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(layout, value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// WithCompletion returns the todo with any completion marker replaced by one
+// for at in style, or removed when style is DateMarkerNone or at is zero.
+func (item TodoItem) WithCompletion(style string, at time.Time) TodoItem {
+	item.Text = strings.TrimSpace(completionRegex.ReplaceAllString(item.Text, ""))
+	if at.IsZero() {
+		return item
+	}
+	switch style {
+	case DateMarkerEmoji:
+		item.Text += " ✅ " + at.Format(dueDateLayout)
+	case DateMarkerToken:
+		item.Text += " done:" + at.Format(doneTimeLayout)
+	}
+	return item
+}
+
+// WithCreated returns the todo with any created marker replaced by one for
+// at in style, or removed when style is DateMarkerNone or at is zero.
+func (item TodoItem) WithCreated(style string, at time.Time) TodoItem {
+	item.Text = strings.TrimSpace(createdRegex.ReplaceAllString(item.Text, ""))
+	if at.IsZero() {
+		return item
+	}
+	switch style {
+	case DateMarkerEmoji:
+		item.Text += " ➕ " + at.Format(dueDateLayout)
+	case DateMarkerToken:
+		item.Text += " created:" + at.Format(dueDateLayout)
+	}
+	return item
+}
+
+// stampsDates reports whether style writes date markers.
+func stampsDates(style string) bool {
+	return style != "" && style != DateMarkerNone
+}
+
+// StampCreated returns item with a created marker for now when the file
+// stamps created dates and item has none yet. Callers use it for todos the
+// user writes, not for pasted or moved ones, which keep their own dates.
+func (tf *TodoFile) StampCreated(item TodoItem, now time.Time) TodoItem {
+	if !stampsDates(tf.Options.CreatedDates) {
+		return item
+	}
+	if _, ok := item.CreatedAt(); ok {
+		return item
+	}
+	return item.WithCreated(tf.Options.CreatedDates, now)
+}
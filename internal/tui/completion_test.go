@@ -0,0 +1,22 @@
+package tui
+
+import "testing"
+
+func TestCreatedDates_CreateStampsPasteKeeps(t *testing.T) {
+	config := DefaultConfig()
+	config.CreatedDates = DateMarkerToken
+	m := newTestModel(t, "- [ ] old\n", config)
+
+	m = press(m, "c", "n", "e", "w", "enter")
+	if got := m.file.GetTodo(1).Text; got != "new created:2026-10-18" {
+		t.Errorf("created todo = %q, want it stamped with the model date", got)
+	}
+
+	m = press(m, "k", "y", "p")
+	if m.file.TodoCount() != 3 {
+		t.Fatalf("expected 3 todos after paste, got %d", m.file.TodoCount())
+	}
+	if got := m.file.GetTodo(1).Text; got != "old" {
+		t.Errorf("pasted todo = %q, want it unchanged", got)
+	}
+}
@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	configDirName  = "jeb-todo-md"
+	configFileName = "config.json"
+)
+
+// Clipboard modes control where yanked todos are copied in addition to the
+// internal register.
+const (
+	ClipboardNone   = "none"
+	ClipboardSystem = "system"
+	ClipboardOSC52  = "osc52"
+)
+
+// Recurrence placements control where the fresh copy of a completed
+// repeating todo goes.
+const (
+	RecurrenceAfter   = "after"
+	RecurrenceInPlace = "in_place"
+)
+
+// Date marker styles control how completion and created dates are written
+// into todo text: nothing, Obsidian Tasks dates ("✅ 2026-10-16",
+// "➕ 2026-10-16"), or tokens ("done:2026-10-16T14:03", "created:2026-10-16").
+const (
+	DateMarkerNone  = "none"
+	DateMarkerEmoji = "emoji"
+	DateMarkerToken = "token"
+)
+
+// Config holds user settings that shape TUI behavior.
+type Config struct {
+	// Clipboard selects where yanked text is also copied: "none", "system" or "osc52".
+	Clipboard string `json:"clipboard"`
+	// Keys overrides bindings by action name, e.g. {"quit": ["Q"]}.
+	Keys map[string][]string `json:"keys"`
+	// Theme names the color palette: "auto", "dark", "light" or "high-contrast".
+	Theme string `json:"theme"`
+	// Colors overrides theme colors by style name, e.g. {"link": "#5f87ff"}.
+	Colors map[string]string `json:"colors"`
+	// VaultRoot is the directory the file switcher indexes and wiki links and
+	// backlinks search. A leading "~" is expanded.
+	VaultRoot string `json:"vault_root"`
+	// NewFileTemplate is the content of files created from the TUI, with
+	// {{title}} and {{date}} placeholders. Empty means DefaultFileTemplate.
+	NewFileTemplate string `json:"new_file_template"`
+	// AutoCheckLinks checks a linked todo once every todo beneath it is done.
+	AutoCheckLinks bool `json:"auto_check_links"`
+	// Recurrence places the fresh copy of a completed repeating todo: "after"
+	// the checked one, or "in_place" with the checked one moved below it.
+	Recurrence string `json:"recurrence"`
+	// CompletionDates marks when a todo is checked: "none", "emoji" or "token".
+	CompletionDates string `json:"completion_dates"`
+	// CreatedDates marks when a todo is added: "none", "emoji" or "token".
+	CreatedDates string `json:"created_dates"`
+}
+
+// DefaultConfig returns the settings used when nothing is configured.
+func DefaultConfig() Config {
+	return Config{
+		Clipboard:       ClipboardNone,
+		Theme:           ThemeAuto,
+		Recurrence:      RecurrenceAfter,
+		CompletionDates: DateMarkerNone,
+		CreatedDates:    DateMarkerNone,
+	}
+}
+
+// FileOptions returns the editing options for todo files opened under c.
+func (c Config) FileOptions() FileOptions {
+	return FileOptions{
+		RecurInPlace:    c.Recurrence == RecurrenceInPlace,
+		CompletionDates: c.CompletionDates,
+		CreatedDates:    c.CreatedDates,
+	}
+}
+
+// validDateMarker reports whether style names a date marker style.
+func validDateMarker(style string) bool {
+	switch style {
+	case DateMarkerNone, DateMarkerEmoji, DateMarkerToken:
+		return true
+	}
+	return false
+}
+
+// VaultRootFor returns the directory wiki links, backlinks, and link checks
+// search: the configured vault root, or the directory of rootFilePath.
+func (c Config) VaultRootFor(rootFilePath string) string {
+	if c.VaultRoot != "" {
+		return ExpandHome(c.VaultRoot)
+	}
+	return filepath.Dir(rootFilePath)
+}
+
+// ConfigPath returns the default config file location:
+// $XDG_CONFIG_HOME/jeb-todo-md/config.json, falling back to ~/.config.
+func ConfigPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, configDirName, configFileName), nil
+}
+
+// LoadConfig reads the JSON config at path on top of DefaultConfig.
+// A missing file is not an error and yields the defaults.
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return config, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate reports the first invalid setting in the config.
+func (c Config) Validate() error {
+	switch c.Clipboard {
+	case ClipboardNone, ClipboardSystem, ClipboardOSC52:
+	default:
+		return fmt.Errorf("invalid clipboard mode: %s (expected none, system, or osc52)", c.Clipboard)
+	}
+	switch c.Recurrence {
+	case RecurrenceAfter, RecurrenceInPlace:
+	default:
+		return fmt.Errorf("invalid recurrence placement: %s (expected after or in_place)", c.Recurrence)
+	}
+	if !validDateMarker(c.CompletionDates) {
+		return fmt.Errorf("invalid completion_dates style: %s (expected none, emoji, or token)", c.CompletionDates)
+	}
+	if !validDateMarker(c.CreatedDates) {
+		return fmt.Errorf("invalid created_dates style: %s (expected none, emoji, or token)", c.CreatedDates)
+	}
+	if _, err := newKeyMap(c.Keys); err != nil {
+		return err
+	}
+	if _, err := themePalette(c.Theme); err != nil {
+		return err
+	}
+	if err := validateColorOverrides(c.Colors); err != nil {
+		return err
+	}
+	return nil
+}
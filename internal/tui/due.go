@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// today returns the start of the current day, the same date the header shows.
+func (m model) today() time.Time {
+	return StartOfDay(m.now())
+}
+
+// dueBadge renders the todo's due date after its text, styled by whether it
+// is overdue, due today, or upcoming. Checked todos get a muted badge, and
+// todos without a due date get none.
+func (m model) dueBadge(item TodoItem) string {
+	due, ok := item.DueDate()
+	if !ok {
+		return ""
+	}
+	today := m.today()
+	label := "due " + dueLabel(due, today)
+	switch {
+	case item.Checked:
+		return "  " + helpStyle.Render(label)
+	case due.Before(today):
+		return "  " + overdueStyle.Render(label)
+	case due.Equal(today):
+		return "  " + dueTodayStyle.Render(label)
+	default:
+		return "  " + upcomingStyle.Render(label)
+	}
+}
+
+// repeatBadge renders a repeating todo's rule, muted, after its text, e.g.
+// "every week".
+func repeatBadge(item TodoItem) string {
+	if rule := item.Recurrence(); rule != "" {
+		return "  " + helpStyle.Render("every "+rule)
+	}
+	return ""
+}
+
+// doneBadge renders when a checked todo was completed, muted, after its text.
+// Todos without a completion date get none.
+func (m model) doneBadge(item TodoItem) string {
+	done, ok := item.CompletedAt()
+	if !ok || !item.Checked {
+		return ""
+	}
+	return "  " + helpStyle.Render("done "+dueLabel(StartOfDay(done), m.today()))
+}
+
+// dueLabel describes due relative to today: "today", "tomorrow",
+// "yesterday", or the date, with the year only when it differs.
+func dueLabel(due, today time.Time) string {
+	switch {
+	case due.Equal(today):
+		return "today"
+	case due.Equal(today.AddDate(0, 0, 1)):
+		return "tomorrow"
+	case due.Equal(today.AddDate(0, 0, -1)):
+		return "yesterday"
+	case due.Year() != today.Year():
+		return due.Format("Jan 2, 2006")
+	}
+	return due.Format("Jan 2")
+}
+
+// recurrenceNotice describes the copy of a repeating todo added on completion.
+func (m model) recurrenceNotice(next TodoItem) string {
+	due, _ := next.DueDate()
+	return fmt.Sprintf("Repeats every %s: next due %s", next.Recurrence(), dueLabel(due, m.today()))
+}
+
+// startDueInput opens the date prompt for the current todo, prefilled with
+// its due date.
+func (m model) startDueInput() (tea.Model, tea.Cmd) {
+	value := ""
+	if due, ok := m.file.GetTodo(m.cursor).DueDate(); ok {
+		value = due.Format(dueDateLayout)
+	}
+	m.mode = ModeDueInput
+	return m.startTextInput(value)
+}
+
+// updateDueInput handles typing a due date at the prompt. An empty value
+// removes the due date.
+func (m model) updateDueInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		var due time.Time
+		if value := strings.TrimSpace(m.textInput.Value()); value != "" {
+			parsed, err := ParseDate(value, m.now())
+			if err != nil {
+				m.statusMessage = fmt.Sprintf("Error: %v", err)
+				return m, nil
+			}
+			due = parsed
+		}
+		m.file.SetTodoText(m.cursor, m.file.GetTodo(m.cursor).WithDueDate(due).Text)
+		if err := m.file.Save(); err != nil {
+			m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+		}
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		return m, nil
+	case key.Matches(msg, m.keys.Cancel):
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultEditor = "vi"
+
+// editorFinishedMsg is sent when the external editor process exits.
+// itemTempPath is set when a single todo's text was being edited.
+type editorFinishedMsg struct {
+	err          error
+	filePath     string
+	todoIdx      int
+	itemTempPath string
+}
+
+// editorCommand builds a command that opens path in the user's editor,
+// positioned at the 1-based line when the editor supports it.
+// $VISUAL takes precedence over $EDITOR; vi is the fallback.
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{defaultEditor}
+	}
+
+	args := fields[1:]
+	switch filepath.Base(fields[0]) {
+	case "code", "code-insiders", "codium", "cursor":
+		args = append(args, "-g", path+":"+strconv.Itoa(line))
+	case "subl", "zed":
+		args = append(args, path+":"+strconv.Itoa(line))
+	default:
+		args = append(args, "+"+strconv.Itoa(line), path)
+	}
+	return exec.Command(fields[0], args...)
+}
+
+// openFileInEditor suspends the TUI and opens the current file at the cursor's line.
+func (m model) openFileInEditor() (tea.Model, tea.Cmd) {
+	line := 1
+	if m.file.TodoCount() > 0 {
+		line = m.file.TodoIndices[m.cursor] + 1
+	}
+	filePath := m.file.Path
+	todoIdx := m.cursor
+	cmd := tea.ExecProcess(editorCommand(filePath, line), func(err error) tea.Msg {
+		return editorFinishedMsg{err: err, filePath: filePath, todoIdx: todoIdx}
+	})
+	return m, cmd
+}
+
+// openItemInEditor writes the current todo's text to a temp file and opens it in the editor.
+func (m model) openItemInEditor() (tea.Model, tea.Cmd) {
+	tempFile, err := os.CreateTemp("", "jeb-todo-*.md")
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	item := m.file.GetTodo(m.cursor)
+	_, writeErr := tempFile.WriteString(item.Text + "\n")
+	closeErr := tempFile.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tempFile.Name())
+		m.statusMessage = fmt.Sprintf("Error: writing temp file: %v", errors.Join(writeErr, closeErr))
+		return m, nil
+	}
+
+	filePath := m.file.Path
+	todoIdx := m.cursor
+	tempPath := tempFile.Name()
+	cmd := tea.ExecProcess(editorCommand(tempPath, 1), func(err error) tea.Msg {
+		return editorFinishedMsg{err: err, filePath: filePath, todoIdx: todoIdx, itemTempPath: tempPath}
+	})
+	return m, cmd
+}
+
+// handleEditorFinished re-parses the file once the editor exits, applying the
+// edited item text first when a single item was being edited.
+func (m model) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.itemTempPath == "" {
+		reload := loadFileCmd(msg.filePath, msg.todoIdx)
+		if msg.err == nil {
+			return m, reload
+		}
+		// The reload clears the status line, so carry the error through it.
+		status := fmt.Sprintf("Error: editor: %v", msg.err)
+		return m, func() tea.Msg {
+			switched := reload().(switchFileMsg)
+			switched.status = status
+			return switched
+		}
+	}
+
+	if msg.err != nil {
+		os.Remove(msg.itemTempPath)
+		m.statusMessage = fmt.Sprintf("Error: editor: %v", msg.err)
+		return m, nil
+	}
+	return m, applyItemEditCmd(msg.filePath, msg.todoIdx, msg.itemTempPath)
+}
+
+// applyItemEditCmd reads edited item text from tempPath, writes it to the todo
+// at todoIdx in a freshly parsed copy of filePath, and reloads the file.
+// Lines are joined with spaces since todos are single-line; empty text leaves the item unchanged.
+func applyItemEditCmd(filePath string, todoIdx int, tempPath string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(tempPath)
+		os.Remove(tempPath)
+		if err != nil {
+			return switchFileMsg{restoreCursor: todoIdx, err: err}
+		}
+
+		todoFile, err := ParseFile(filePath)
+		if err != nil {
+			return switchFileMsg{restoreCursor: todoIdx, err: err}
+		}
+
+		var lines []string
+		for _, line := range strings.Split(string(data), "\n") {
+			if trimmed := strings.TrimSpace(line); trimmed != "" {
+				lines = append(lines, trimmed)
+			}
+		}
+		text := strings.Join(lines, " ")
+		if text != "" && todoIdx < todoFile.TodoCount() {
+			todoFile.SetTodoText(todoIdx, text)
+			if err := todoFile.Save(); err != nil {
+				return switchFileMsg{restoreCursor: todoIdx, err: fmt.Errorf("saving: %w", err)}
+			}
+		}
+		return switchFileMsg{newFile: todoFile, restoreCursor: todoIdx}
+	}
+}
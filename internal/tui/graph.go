@@ -0,0 +1,111 @@
+package tui
+
+import "path/filepath"
+
+// GraphNode is one file reached while walking links from a root file.
+type GraphNode struct {
+	// Path is the file's absolute path.
+	Path string
+	// File is the parsed file, or nil if it could not be read.
+	File *TodoFile
+	// Err holds the parse error when File is nil.
+	Err error
+	// Depth is the fewest links needed to reach the file from the root.
+	Depth int
+}
+
+// GraphEdge is one linked todo, from the file containing it to its target.
+type GraphEdge struct {
+	// From and To are absolute paths of the linking and linked files.
+	From string
+	To   string
+	// TodoIndex is the linking todo's logical index in From.
+	TodoIndex int
+	Item      TodoItem
+}
+
+// LinkGraph is every file reachable from a root file by following links,
+// with every link between them. Unlike LinkNode trees, each file appears once.
+type LinkGraph struct {
+	// Nodes lists files in breadth-first order, root first.
+	Nodes []*GraphNode
+	// Edges lists links in the order their source files were visited.
+	Edges []GraphEdge
+
+	byPath map[string]*GraphNode
+}
+
+// BuildLinkGraph walks links breadth-first from rootPath, resolving them with
+// resolver. Files that cannot be read become nodes with Err set and are not
+// followed further; the root is included even when unreadable.
+func BuildLinkGraph(rootPath string, resolver *LinkResolver) *LinkGraph {
+	graph := &LinkGraph{byPath: map[string]*GraphNode{}}
+	queue := []*GraphNode{graph.add(rootPath, 0)}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.File == nil {
+			continue
+		}
+		for i := 0; i < node.File.TodoCount(); i++ {
+			item := node.File.GetTodo(i)
+			linkedPath := resolver.Resolve(node.Path, item)
+			if linkedPath == "" {
+				continue
+			}
+			target := graph.Node(linkedPath)
+			if target == nil {
+				target = graph.add(linkedPath, node.Depth+1)
+				queue = append(queue, target)
+			}
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:      node.Path,
+				To:        target.Path,
+				TodoIndex: i,
+				Item:      item,
+			})
+		}
+	}
+	return graph
+}
+
+// add parses path and records it as a new node.
+func (g *LinkGraph) add(path string, depth int) *GraphNode {
+	node := &GraphNode{Path: absPath(path), Depth: depth}
+	node.File, node.Err = ParseFile(node.Path)
+	g.Nodes = append(g.Nodes, node)
+	g.byPath[node.Path] = node
+	return node
+}
+
+// Root returns the node the graph was built from.
+func (g *LinkGraph) Root() *GraphNode {
+	return g.Nodes[0]
+}
+
+// Node returns the node for path, or nil if it was not reached.
+func (g *LinkGraph) Node(path string) *GraphNode {
+	return g.byPath[absPath(path)]
+}
+
+// OutEdges returns the links from the file at path, in todo order.
+func (g *LinkGraph) OutEdges(path string) []GraphEdge {
+	from := absPath(path)
+	var edges []GraphEdge
+	for _, edge := range g.Edges {
+		if edge.From == from {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// absPath returns path made absolute, or cleaned if that fails.
+func absPath(path string) string {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return absolutePath
+}
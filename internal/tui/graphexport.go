@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graph export formats accepted by FormatGraph.
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// FormatGraph renders graph as a Graphviz DOT or Mermaid flowchart. Each file
+// is one node labeled with its path relative to root and its open/done todo
+// counts; each link is one edge, dashed when the linking todo is checked.
+// Unreadable files are drawn dashed and labeled "missing".
+func FormatGraph(graph *LinkGraph, root, format string) (string, error) {
+	switch format {
+	case GraphFormatDOT:
+		return formatDOT(graph, root), nil
+	case GraphFormatMermaid:
+		return formatMermaid(graph, root), nil
+	default:
+		return "", fmt.Errorf("unknown graph format %q (want %s or %s)", format, GraphFormatDOT, GraphFormatMermaid)
+	}
+}
+
+// graphNodeIDs assigns stable node ids in graph order: n0 is the root.
+func graphNodeIDs(graph *LinkGraph) map[string]string {
+	ids := make(map[string]string, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		ids[node.Path] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// graphNodeCounts returns the label's second line: open/done counts, or
+// "missing" for unreadable files.
+func graphNodeCounts(node *GraphNode) string {
+	if node.File == nil {
+		return "missing"
+	}
+	done := 0
+	for i := 0; i < node.File.TodoCount(); i++ {
+		if node.File.GetTodo(i).Checked {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d open, %d done", node.File.TodoCount()-done, done)
+}
+
+func formatDOT(graph *LinkGraph, root string) string {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	ids := graphNodeIDs(graph)
+
+	var b strings.Builder
+	b.WriteString("digraph todos {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range graph.Nodes {
+		label := quote.Replace(RelativeDisplayPath(root, node.Path)) + `\n` + graphNodeCounts(node)
+		attributes := fmt.Sprintf(`label="%s"`, label)
+		if node.File == nil {
+			attributes += ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", ids[node.Path], attributes)
+	}
+	for _, edge := range graph.Edges {
+		attributes := ""
+		if edge.Item.Checked {
+			attributes = " [style=dashed, color=gray]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", ids[edge.From], ids[edge.To], attributes)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func formatMermaid(graph *LinkGraph, root string) string {
+	quote := strings.NewReplacer(`"`, "#quot;")
+	ids := graphNodeIDs(graph)
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	var missing []string
+	for _, node := range graph.Nodes {
+		label := quote.Replace(RelativeDisplayPath(root, node.Path)) + "<br/>" + graphNodeCounts(node)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node.Path], label)
+		if node.File == nil {
+			missing = append(missing, ids[node.Path])
+		}
+	}
+	for _, edge := range graph.Edges {
+		arrow := "-->"
+		if edge.Item.Checked {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+	}
+	if len(missing) > 0 {
+		b.WriteString("  classDef missing stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&b, "  class %s missing\n", strings.Join(missing, ","))
+	}
+	return b.String()
+}
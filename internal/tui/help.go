@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpSection groups the bindings that apply in one mode.
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// bindingEntry describes a binding with all of its keys.
+func bindingEntry(b key.Binding, desc string) helpEntry {
+	return helpEntry{keys: b.Help().Key, desc: desc}
+}
+
+// helpSections lists every binding for every mode, built from the active keymap.
+func (m model) helpSections() []helpSection {
+	keys := m.keys
+	return []helpSection{
+		{"Normal", []helpEntry{
+			bindingEntry(keys.Down, "move down"),
+			bindingEntry(keys.Up, "move up"),
+			bindingEntry(keys.Toggle, "toggle checkbox, or open linked todo"),
+			bindingEntry(keys.ToggleOnly, "toggle checkbox (even on linked items)"),
+			bindingEntry(keys.Edit, "edit current item"),
+			bindingEntry(keys.EditExternal, "edit current item in $EDITOR"),
+			bindingEntry(keys.OpenFile, "open file in $EDITOR at cursor"),
+			bindingEntry(keys.Create, "create item below cursor"),
+			bindingEntry(keys.Rearrange, "enter rearrange mode"),
+			{keys.Delete.Help().Key + " " + keys.Delete.Help().Key, "delete current item"},
+			bindingEntry(keys.Yank, "yank current item"),
+			bindingEntry(keys.Select, "start selection"),
+			bindingEntry(keys.Paste, "paste below cursor"),
+			bindingEntry(keys.PasteAbove, "paste above cursor"),
+			bindingEntry(keys.Preview, "toggle linked file preview"),
+			bindingEntry(keys.Tree, "show tree of all linked files"),
+			bindingEntry(keys.Switcher, "fuzzy-find a file to open"),
+			bindingEntry(keys.NewLinked, "create a new linked list and open it"),
+			bindingEntry(keys.Backlinks, "list items in other files that link here"),
+			bindingEntry(keys.Query, "search todos across all linked files"),
+			bindingEntry(keys.Due, "set or clear the due date"),
+			{pairLabel(keys.Raise, keys.Lower), "raise/lower priority"},
+			bindingEntry(keys.Sort, "sort each section by priority, then due date"),
+			bindingEntry(keys.Tags, "filter the list by #tag, @context, or +project"),
+			{keys.Breadcrumb.Help().Key + " 1-9", "jump back to an ancestor in the breadcrumb"},
+			bindingEntry(keys.Help, "show this help"),
+			bindingEntry(keys.Quit, "quit, or go back from a linked file"),
+		}},
+		{"Rearrange", []helpEntry{
+			bindingEntry(keys.Down, "swap item down"),
+			bindingEntry(keys.Up, "swap item up"),
+			{pairLabel(keys.Rearrange, keys.Cancel), "done rearranging"},
+		}},
+		{"Select", []helpEntry{
+			bindingEntry(keys.Down, "extend selection down"),
+			bindingEntry(keys.Up, "extend selection up"),
+			bindingEntry(keys.Yank, "yank selection"),
+			{pairLabel(keys.Select, keys.Cancel), "cancel selection"},
+		}},
+		{"Tree", []helpEntry{
+			bindingEntry(keys.Down, "move down"),
+			bindingEntry(keys.Up, "move up"),
+			bindingEntry(keys.Expand, "expand linked file"),
+			bindingEntry(keys.Collapse, "collapse, or go to parent"),
+			bindingEntry(keys.Confirm, "open linked file, or jump to item"),
+			{pairLabel(keys.Tree, keys.Cancel), "close tree"},
+		}},
+		{"Backlinks", []helpEntry{
+			bindingEntry(keys.Down, "move down"),
+			bindingEntry(keys.Up, "move up"),
+			bindingEntry(keys.Confirm, "open linking file at the item"),
+			{pairLabel(keys.Backlinks, keys.Cancel), "close backlinks"},
+		}},
+		{"Query results", []helpEntry{
+			bindingEntry(keys.Down, "move down"),
+			bindingEntry(keys.Up, "move up"),
+			{pairLabel(keys.Toggle, keys.ToggleOnly), "toggle checkbox in its file"},
+			bindingEntry(keys.Edit, "edit text in its file"),
+			bindingEntry(keys.Confirm, "open its file at the item"),
+			bindingEntry(keys.Query, "change the query"),
+			bindingEntry(keys.Quit, "close results"),
+		}},
+		{"Tag filter", []helpEntry{
+			bindingEntry(keys.Down, "move down"),
+			bindingEntry(keys.Up, "move up"),
+			{pairLabel(keys.Toggle, keys.ToggleOnly), "select or deselect a label"},
+			bindingEntry(keys.Confirm, "show todos with any selected label, or all if none"),
+			{pairLabel(keys.Tags, keys.Cancel), "cancel"},
+		}},
+		{"File switcher", []helpEntry{
+			bindingEntry(keys.NextMatch, "next match"),
+			bindingEntry(keys.PrevMatch, "previous match"),
+			bindingEntry(keys.Confirm, "open file, or create and link it"),
+			bindingEntry(keys.Cancel, "cancel"),
+		}},
+		{"Edit / Create / New list / Query / Due", []helpEntry{
+			bindingEntry(keys.Confirm, "save"),
+			bindingEntry(keys.Cancel, "cancel"),
+		}},
+		{"Help", []helpEntry{
+			bindingEntry(keys.Down, "scroll down"),
+			bindingEntry(keys.Up, "scroll up"),
+			{pairLabel(keys.Help, keys.Cancel), "close help"},
+		}},
+		{"Any mode", []helpEntry{
+			bindingEntry(keys.ForceQuit, "force quit"),
+		}},
+	}
+}
+
+// helpLines renders all help sections as lines, aligning descriptions.
+func (m model) helpLines() []string {
+	sections := m.helpSections()
+
+	keyWidth := 0
+	for _, section := range sections {
+		for _, entry := range section.entries {
+			keyWidth = max(keyWidth, lipgloss.Width(entry.keys))
+		}
+	}
+
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.UnsetMarginBottom().Render("  "+section.title))
+		for _, entry := range section.entries {
+			lines = append(lines, fmt.Sprintf("    %s  %s", cursorStyle.Render(padRight(entry.keys, keyWidth)), entry.desc))
+		}
+	}
+	return lines
+}
+
+// padRight pads s with spaces to the given display width.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+}
+
+// helpViewportHeight returns how many help lines fit between the header and footer.
+// A zero height (size not yet known) shows everything.
+func (m model) helpViewportHeight() int {
+	if m.height == 0 {
+		return len(m.helpLines())
+	}
+	chrome := lipgloss.Height(m.renderHeader()) + 2
+	return max(1, m.height-chrome)
+}
+
+// maxHelpScroll returns the largest valid scroll offset for the help overlay.
+func (m model) maxHelpScroll() int {
+	return max(0, len(m.helpLines())-m.helpViewportHeight())
+}
+
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.helpScroll < m.maxHelpScroll() {
+			m.helpScroll++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.helpScroll > 0 {
+			m.helpScroll--
+		}
+	case key.Matches(msg, m.keys.Help, m.keys.Cancel, m.keys.Quit):
+		m.mode = ModeNormal
+	}
+	return m, nil
+}
+
+// renderHelpOverlay renders the visible window of the help overlay.
+func (m model) renderHelpOverlay() string {
+	lines := m.helpLines()
+	start := min(m.helpScroll, m.maxHelpScroll())
+	end := min(len(lines), start+m.helpViewportHeight())
+	return strings.Join(lines[start:end], "\n")
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHelpSections_HelpKeyIsNormalMode(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n", DefaultConfig())
+	for _, section := range m.helpSections() {
+		hasHelp := slices.ContainsFunc(section.entries, func(entry helpEntry) bool {
+			return entry.desc == "show this help"
+		})
+		if hasHelp != (section.title == "Normal") {
+			t.Errorf("section %q lists the help key: %v", section.title, hasHelp)
+		}
+	}
+}
+
+func TestHelpOverlay_Renders(t *testing.T) {
+	config := DefaultConfig()
+	config.Keys = map[string][]string{"create": {"a"}}
+	m := newTestModel(t, "- [ ] one\n", config)
+	m.height = 0 // show every line
+
+	m = press(m, "?")
+	if m.mode != ModeHelp {
+		t.Fatalf("mode = %v, want ModeHelp", m.mode)
+	}
+	view := m.View()
+	for _, want := range []string{"Normal", "create item below cursor", "Any mode", "force quit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("help overlay is missing %q", want)
+		}
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "create item below cursor") && !strings.Contains(line, "a ") {
+			t.Errorf("create entry does not show the configured key: %q", line)
+		}
+	}
+
+	m = press(m, "?")
+	if m.mode != ModeNormal {
+		t.Errorf("mode after closing help = %v, want ModeNormal", m.mode)
+	}
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// todoTxtDoneRegex matches a completed todo.txt task: "x", a completion
+	// date, and an optional creation date.
+	todoTxtDoneRegex = regexp.MustCompile(`^x (\d{4}-\d{2}-\d{2}) (?:(\d{4}-\d{2}-\d{2}) )?`)
+	// todoTxtOpenRegex matches an open todo.txt task's optional priority and
+	// creation date.
+	todoTxtOpenRegex = regexp.MustCompile(`^(\([A-Z]\) )?(\d{4}-\d{2}-\d{2}) `)
+	// bulletRegex matches a plain markdown list bullet.
+	bulletRegex = regexp.MustCompile(`^[-*+] `)
+)
+
+// ParseInputLine turns one line of piped input into a todo. It accepts
+// markdown todo lines ("- [ ] text", "- [x] text"), todo.txt lines, and
+// plain text, with any list bullet removed. A todo.txt "x YYYY-MM-DD"
+// prefix marks the item done; its completion and creation dates become "✅"
+// and "➕" markers and a "(A)" priority is kept at the start of the text.
+// Blank lines return false.
+func ParseInputLine(line string) (TodoItem, bool) {
+	if item := ParseTodoLine(line); item != nil {
+		item.Text = strings.TrimSpace(item.Text)
+		return *item, item.Text != ""
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return TodoItem{}, false
+	}
+
+	item := TodoItem{Text: line}
+	var created, completed string
+	if match := todoTxtDoneRegex.FindStringSubmatch(line); match != nil {
+		item.Checked = true
+		item.Text = line[len(match[0]):]
+		completed, created = match[1], match[2]
+	} else if match := todoTxtOpenRegex.FindStringSubmatch(line); match != nil {
+		item.Text = match[1] + line[len(match[0]):]
+		created = match[2]
+	} else {
+		item.Text = bulletRegex.ReplaceAllString(line, "")
+	}
+	item.Text = strings.TrimSpace(item.Text)
+	if item.Text == "" {
+		return TodoItem{}, false
+	}
+	if at, ok := parseLocal(dueDateLayout, created); ok {
+		item = item.WithCreated(DateMarkerEmoji, at)
+	}
+	if at, ok := parseLocal(dueDateLayout, completed); ok {
+		item = item.WithCompletion(DateMarkerEmoji, at)
+	}
+	return item, true
+}
@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds every configurable binding. Bindings are shared across modes
+// where the meaning carries over (e.g. Down navigates in normal mode and
+// swaps in rearrange mode).
+type keyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	Toggle       key.Binding
+	ToggleOnly   key.Binding
+	Edit         key.Binding
+	EditExternal key.Binding
+	OpenFile     key.Binding
+	Create       key.Binding
+	Rearrange    key.Binding
+	Delete       key.Binding
+	Yank         key.Binding
+	Select       key.Binding
+	Paste        key.Binding
+	PasteAbove   key.Binding
+	Preview      key.Binding
+	Tree         key.Binding
+	Breadcrumb   key.Binding
+	Switcher     key.Binding
+	NewLinked    key.Binding
+	Backlinks    key.Binding
+	Query        key.Binding
+	Due          key.Binding
+	Raise        key.Binding
+	Lower        key.Binding
+	Sort         key.Binding
+	Tags         key.Binding
+	NextMatch    key.Binding
+	PrevMatch    key.Binding
+	Expand       key.Binding
+	Collapse     key.Binding
+	Quit         key.Binding
+	Help         key.Binding
+	Confirm      key.Binding
+	Cancel       key.Binding
+	ForceQuit    key.Binding
+}
+
+// defaultKeyMap returns the built-in vim-style bindings.
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Up:           newBinding("navigate up", "k", "up"),
+		Down:         newBinding("navigate down", "j", "down"),
+		Toggle:       newBinding("toggle/open", " ", "enter"),
+		ToggleOnly:   newBinding("toggle", "x"),
+		Edit:         newBinding("edit", "e"),
+		EditExternal: newBinding("edit in $EDITOR", "E"),
+		OpenFile:     newBinding("open file in $EDITOR", "O"),
+		Create:       newBinding("create", "c"),
+		Rearrange:    newBinding("rearrange", "r"),
+		Delete:       newBinding("delete", "d"),
+		Yank:         newBinding("yank", "y"),
+		Select:       newBinding("select", "v"),
+		Paste:        newBinding("paste below", "p"),
+		PasteAbove:   newBinding("paste above", "P"),
+		Preview:      newBinding("toggle preview", "tab"),
+		Tree:         newBinding("tree view", "t"),
+		Breadcrumb:   newBinding("jump to ancestor", "b"),
+		Switcher:     newBinding("switch file", "ctrl+p"),
+		NewLinked:    newBinding("new linked list", "N"),
+		Backlinks:    newBinding("backlinks", "B"),
+		Query:        newBinding("query", "/"),
+		Due:          newBinding("set due date", "D"),
+		Raise:        newBinding("raise priority", "+"),
+		Lower:        newBinding("lower priority", "-"),
+		Sort:         newBinding("sort sections", "S"),
+		Tags:         newBinding("filter by tag", "#"),
+		NextMatch:    newBinding("next match", "down", "ctrl+n"),
+		PrevMatch:    newBinding("previous match", "up", "ctrl+p"),
+		Expand:       newBinding("expand", "l", "right"),
+		Collapse:     newBinding("collapse", "h", "left"),
+		Quit:         newBinding("quit/back", "q", "esc"),
+		Help:         newBinding("help", "?"),
+		Confirm:      newBinding("confirm", "enter"),
+		Cancel:       newBinding("cancel", "esc"),
+		ForceQuit:    newBinding("force quit", "ctrl+c"),
+	}
+}
+
+// newBinding creates a binding whose help label lists all of its keys.
+func newBinding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keysLabel(keys), desc))
+}
+
+// keysLabel joins keys for display, spelling out the space key.
+func keysLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// bindingsByAction maps config action names to the bindings they control.
+func (km *keyMap) bindingsByAction() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":            &km.Up,
+		"down":          &km.Down,
+		"toggle":        &km.Toggle,
+		"toggle_only":   &km.ToggleOnly,
+		"edit":          &km.Edit,
+		"edit_external": &km.EditExternal,
+		"open_file":     &km.OpenFile,
+		"create":        &km.Create,
+		"rearrange":     &km.Rearrange,
+		"delete":        &km.Delete,
+		"yank":          &km.Yank,
+		"select":        &km.Select,
+		"paste":         &km.Paste,
+		"paste_above":   &km.PasteAbove,
+		"preview":       &km.Preview,
+		"tree":          &km.Tree,
+		"breadcrumb":    &km.Breadcrumb,
+		"switcher":      &km.Switcher,
+		"new_linked":    &km.NewLinked,
+		"backlinks":     &km.Backlinks,
+		"query":         &km.Query,
+		"due":           &km.Due,
+		"raise":         &km.Raise,
+		"lower":         &km.Lower,
+		"sort":          &km.Sort,
+		"tags":          &km.Tags,
+		"next_match":    &km.NextMatch,
+		"prev_match":    &km.PrevMatch,
+		"expand":        &km.Expand,
+		"collapse":      &km.Collapse,
+		"quit":          &km.Quit,
+		"help":          &km.Help,
+		"confirm":       &km.Confirm,
+		"cancel":        &km.Cancel,
+		"force_quit":    &km.ForceQuit,
+	}
+}
+
+// newKeyMap returns the default keymap with overrides applied.
+// Overrides map action names to key lists; "space" is accepted for the space bar.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	km := defaultKeyMap()
+	bindings := km.bindingsByAction()
+
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		binding, ok := bindings[action]
+		if !ok {
+			return keyMap{}, fmt.Errorf("unknown key action: %s", action)
+		}
+		keys := slices.Clone(overrides[action])
+		if len(keys) == 0 {
+			return keyMap{}, fmt.Errorf("no keys given for action: %s", action)
+		}
+		for i, k := range keys {
+			if k == "space" {
+				keys[i] = " "
+			}
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(keysLabel(keys), binding.Help().Desc)
+	}
+	if err := checkKeyConflicts(bindings); err != nil {
+		return keyMap{}, err
+	}
+	return km, nil
+}
+
+// modeActions lists every action each mode reads. shared names pairs of
+// actions a mode treats alike, such as quit and cancel both leaving the
+// tree, or confirm taking enter before toggle in the query view, so their
+// overlapping default keys are allowed.
+var modeActions = []struct {
+	mode    string
+	actions []string
+	shared  [][2]string
+}{
+	{"normal", []string{"up", "down", "toggle", "toggle_only", "edit", "edit_external", "open_file", "create", "rearrange", "delete", "yank", "select", "paste", "paste_above", "preview", "tree", "breadcrumb", "switcher", "new_linked", "backlinks", "query", "due", "raise", "lower", "sort", "tags", "quit", "help", "force_quit"}, nil},
+	{"rearrange and select", []string{"up", "down", "rearrange", "select", "yank", "cancel", "force_quit"}, nil},
+	{"prompt", []string{"confirm", "cancel", "force_quit"}, nil},
+	{"tree", []string{"up", "down", "expand", "collapse", "confirm", "tree", "quit", "cancel", "force_quit"}, [][2]string{{"quit", "cancel"}}},
+	{"switcher", []string{"next_match", "prev_match", "confirm", "cancel", "force_quit"}, nil},
+	{"backlinks", []string{"up", "down", "confirm", "backlinks", "cancel", "force_quit"}, nil},
+	{"query", []string{"up", "down", "confirm", "toggle", "toggle_only", "edit", "query", "quit", "force_quit"}, [][2]string{{"confirm", "toggle"}}},
+	{"tag picker", []string{"up", "down", "confirm", "toggle", "toggle_only", "tags", "cancel", "force_quit"}, [][2]string{{"confirm", "toggle"}}},
+	{"help", []string{"up", "down", "help", "quit", "cancel", "force_quit"}, [][2]string{{"quit", "cancel"}}},
+}
+
+// checkKeyConflicts reports the first key bound to two actions in one mode.
+func checkKeyConflicts(bindings map[string]*key.Binding) error {
+	for _, mode := range modeActions {
+		owners := map[string]string{}
+		for _, action := range mode.actions {
+			for _, k := range bindings[action].Keys() {
+				owner, ok := owners[k]
+				if ok && owner != action && !slices.Contains(mode.shared, [2]string{owner, action}) {
+					return fmt.Errorf("key %q is bound to both %s and %s in %s mode", keysLabel([]string{k}), owner, action, mode.mode)
+				}
+				owners[k] = action
+			}
+		}
+	}
+	return nil
+}
+
+// firstKeyLabel returns the display label of a binding's primary key.
+func firstKeyLabel(b key.Binding) string {
+	keys := b.Keys()
+	if len(keys) == 0 {
+		return ""
+	}
+	return keysLabel(keys[:1])
+}
+
+// helpEntry is one "keys: description" pair in the help line.
+type helpEntry struct {
+	keys string
+	desc string
+}
+
+// renderHelpEntries formats entries in the footer's "keys: desc" layout.
+func renderHelpEntries(entries []helpEntry) string {
+	parts := make([]string, len(entries))
+	for i, entry := range entries {
+		parts[i] = entry.keys + ": " + entry.desc
+	}
+	return "  " + strings.Join(parts, "  ")
+}
+
+// pairLabel combines the primary keys of two bindings, e.g. "j/k".
+func pairLabel(a, b key.Binding) string {
+	return firstKeyLabel(a) + "/" + firstKeyLabel(b)
+}
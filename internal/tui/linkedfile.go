@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultFileTemplate is used for new linked files when no template is configured.
+// {{title}} is replaced with the file name without extension and {{date}} with
+// today's date (YYYY-MM-DD).
+const DefaultFileTemplate = `---
+created: {{date}}
+---
+
+# {{title}}
+`
+
+// RenderFileTemplate fills the {{title}} and {{date}} placeholders in template.
+func RenderFileTemplate(template, title string, now time.Time) string {
+	return strings.NewReplacer(
+		"{{title}}", title,
+		"{{date}}", now.Format("2006-01-02"),
+	).Replace(template)
+}
+
+// linkPathFrom returns targetPath as a slash-separated path relative to the
+// directory of fromFilePath, suitable for a todo: link.
+func linkPathFrom(fromFilePath, targetPath string) string {
+	fromDir, err := filepath.Abs(filepath.Dir(fromFilePath))
+	if err != nil {
+		return targetPath
+	}
+	absoluteTarget, err := filepath.Abs(targetPath)
+	if err != nil {
+		return targetPath
+	}
+	relativePath, err := filepath.Rel(fromDir, absoluteTarget)
+	if err != nil {
+		return absoluteTarget
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+// createAndLinkFile creates a new todo file at targetPath from the configured template, inserts a todo:
+// link to it below the cursor, and navigates into it.
+func (m model) createAndLinkFile(targetPath string) (tea.Model, tea.Cmd) {
+	if _, err := os.Stat(targetPath); err == nil {
+		m.statusMessage = fmt.Sprintf("Error: file already exists: %s", targetPath)
+		return m, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	template := m.config.NewFileTemplate
+	if template == "" {
+		template = DefaultFileTemplate
+	}
+	content := RenderFileTemplate(template, fileBasenameWithoutExtension(targetPath), m.now())
+	if err := os.WriteFile(targetPath, []byte(content), defaultFilePermission); err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+
+	linkItem := TodoItem{Text: "todo:" + linkPathFrom(m.file.Path, targetPath)}
+	m.file.InsertTodo(m.cursor, linkItem)
+	if m.file.TodoCount() > 1 {
+		m.cursor++
+	}
+	if err := m.file.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+		return m, nil
+	}
+	return m.navigateToLinkedFile(linkItem)
+}
+
+// newLinkedFilePath resolves a name typed at the new-list prompt to a file
+// path next to the current file, adding ".md" when no markdown extension is given.
+func (m model) newLinkedFilePath(name string) string {
+	if !markdownExtensions[strings.ToLower(filepath.Ext(name))] {
+		name += ".md"
+	}
+	return ResolveLinkedPath(m.file.Path, name)
+}
+
+func (m model) updateNewLinked(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		name := strings.TrimSpace(m.textInput.Value())
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		if name == "" {
+			return m, nil
+		}
+		return m.createAndLinkFile(m.newLinkedFilePath(name))
+	case key.Matches(msg, m.keys.Cancel):
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
@@ -0,0 +1,23 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCreateAndLinkFile_UsesModelClock(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n", DefaultConfig())
+	m.now = func() time.Time { return time.Date(2020, 1, 2, 9, 0, 0, 0, time.Local) }
+
+	m = press(m, "N", "p", "l", "a", "n", "enter")
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(m.file.Path), "plan.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RenderFileTemplate(DefaultFileTemplate, "plan", m.now())
+	if string(data) != want {
+		t.Errorf("new file =\n%s\nwant\n%s", data, want)
+	}
+}
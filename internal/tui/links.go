@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LinkKind identifies the syntax a linked todo uses.
+type LinkKind int
+
+const (
+	// LinkNone means the todo does not link to another file.
+	LinkNone LinkKind = iota
+	// LinkTodo is the bare "todo:<path>" prefix.
+	LinkTodo
+	// LinkMarkdown is a markdown link to a markdown file, "[label](path.md)".
+	LinkMarkdown
+	// LinkWiki is an Obsidian-style wiki link, "[[Note]]" or "[[Note|label]]".
+	LinkWiki
+)
+
+var (
+	// wikiLinkRegex captures the note name and optional label, ignoring any #heading.
+	wikiLinkRegex = regexp.MustCompile(`\[\[([^\]|#]+)(?:#[^\]|]*)?(?:\|([^\]]+))?\]\]`)
+	// markdownLinkRegex captures the label and target of a link to a .md/.markdown file.
+	markdownLinkRegex = regexp.MustCompile(`\[([^\]]*)\]\(<?([^)<>]+?\.(?:md|markdown))>?\)`)
+)
+
+// Link describes the first file link found in a todo's text.
+type Link struct {
+	Kind LinkKind
+	// Target is the path (todo:, markdown) or note name (wiki) being linked.
+	Target string
+	// Label is the text to display in place of the link syntax.
+	Label string
+}
+
+// Link returns the todo's file link. A "todo:" prefix, optionally after a
+// priority marker in any syntax, takes precedence, with any dates, priority
+// marker, or labels written as separate words after the path ignored;
+// otherwise the first wiki or markdown link anywhere in the text is used.
+// URLs in markdown links are not treated as file links.
+func (item TodoItem) Link() Link {
+	if rest, ok := strings.CutPrefix(stripPriority(item.Text), "todo:"); ok {
+		target := stripTrailingLabels(stripMetadata(rest))
+		return Link{Kind: LinkTodo, Target: target, Label: strings.TrimSuffix(target, filepath.Ext(target))}
+	}
+
+	wikiMatch := wikiLinkRegex.FindStringSubmatchIndex(item.Text)
+	var markdownMatch []int
+	for _, match := range markdownLinkRegex.FindAllStringSubmatchIndex(item.Text, -1) {
+		if !strings.Contains(item.Text[match[4]:match[5]], "://") {
+			markdownMatch = match
+			break
+		}
+	}
+
+	if wikiMatch != nil && (markdownMatch == nil || wikiMatch[0] < markdownMatch[0]) {
+		target := strings.TrimSpace(item.Text[wikiMatch[2]:wikiMatch[3]])
+		label := target
+		if wikiMatch[4] >= 0 {
+			label = strings.TrimSpace(item.Text[wikiMatch[4]:wikiMatch[5]])
+		}
+		return Link{Kind: LinkWiki, Target: target, Label: label}
+	}
+	if markdownMatch != nil {
+		target := item.Text[markdownMatch[4]:markdownMatch[5]]
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		label := item.Text[markdownMatch[2]:markdownMatch[3]]
+		if label == "" {
+			label = strings.TrimSuffix(path.Base(target), path.Ext(target))
+		}
+		return Link{Kind: LinkMarkdown, Target: strings.TrimSpace(target), Label: label}
+	}
+	return Link{}
+}
+
+// DisplayText returns the todo text with wiki and markdown link syntax
+// replaced by the link labels and any dates, repeat rule, or priority marker
+// removed.
+// Plain text and todo: links are otherwise unchanged.
+func (item TodoItem) DisplayText() string {
+	text := wikiLinkRegex.ReplaceAllStringFunc(item.Text, func(match string) string {
+		parts := wikiLinkRegex.FindStringSubmatch(match)
+		if parts[2] != "" {
+			return strings.TrimSpace(parts[2])
+		}
+		return strings.TrimSpace(parts[1])
+	})
+	text = markdownLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := markdownLinkRegex.FindStringSubmatch(match)
+		if strings.Contains(parts[2], "://") {
+			return match
+		}
+		if parts[1] != "" {
+			return parts[1]
+		}
+		return strings.TrimSuffix(path.Base(parts[2]), path.Ext(parts[2]))
+	})
+	return stripMetadata(text)
+}
+
+// LinkResolver turns todo links into file paths. todo: and markdown links
+// resolve relative to the linking file; wiki links resolve by note name,
+// first next to the linking file and then anywhere under the vault root.
+// A nil *LinkResolver resolves wiki links next to the linking file only.
+type LinkResolver struct {
+	VaultRoot string
+
+	indexOnce sync.Once
+	// notesByName maps lower-cased note names (file name without extension)
+	// to the first matching file under VaultRoot.
+	notesByName map[string]string
+}
+
+// NewLinkResolver returns a resolver that searches vaultRoot for wiki links.
+// A relative vaultRoot is made absolute so resolved paths are too.
+func NewLinkResolver(vaultRoot string) *LinkResolver {
+	if vaultRoot != "" {
+		vaultRoot = absPath(vaultRoot)
+	}
+	return &LinkResolver{VaultRoot: vaultRoot}
+}
+
+// Resolve returns the file path item links to, or "" if it is not a link.
+func (r *LinkResolver) Resolve(currentFilePath string, item TodoItem) string {
+	link := item.Link()
+	if link.Target == "" {
+		return ""
+	}
+	if link.Kind != LinkWiki {
+		return ResolveLinkedPath(currentFilePath, link.Target)
+	}
+
+	notePath := link.Target
+	if !markdownExtensions[strings.ToLower(filepath.Ext(notePath))] {
+		notePath += ".md"
+	}
+	besideCurrent := ResolveLinkedPath(currentFilePath, notePath)
+	if r == nil || r.VaultRoot == "" || fileExists(besideCurrent) {
+		return besideCurrent
+	}
+	if strings.Contains(link.Target, "/") {
+		if inVault := filepath.Join(r.VaultRoot, filepath.FromSlash(notePath)); fileExists(inVault) {
+			return inVault
+		}
+		return besideCurrent
+	}
+	if found, ok := r.noteIndex()[strings.ToLower(link.Target)]; ok {
+		return found
+	}
+	return besideCurrent
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// noteIndex lazily indexes every markdown file under VaultRoot by note name.
+func (r *LinkResolver) noteIndex() map[string]string {
+	r.indexOnce.Do(func() {
+		r.notesByName = map[string]string{}
+		files, err := ListMarkdownFiles(r.VaultRoot)
+		if err != nil {
+			return
+		}
+		for _, file := range files {
+			name := strings.ToLower(strings.TrimSuffix(path.Base(file), path.Ext(file)))
+			if _, taken := r.notesByName[name]; !taken {
+				r.notesByName[name] = filepath.Join(r.VaultRoot, filepath.FromSlash(file))
+			}
+		}
+	})
+	return r.notesByName
+}
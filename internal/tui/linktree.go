@@ -0,0 +1,73 @@
+package tui
+
+import "path/filepath"
+
+// LinkNode is one file in the hierarchy reached by following links.
+type LinkNode struct {
+	// Path is the file path as resolved from the parent's link.
+	Path string
+	// File is the parsed file, or nil if it could not be read.
+	File *TodoFile
+	// Err holds the parse error when File is nil.
+	Err error
+	// Cycle is true when the file already appears among its ancestors.
+	// Cyclic nodes are not expanded.
+	Cycle bool
+	// Children maps the logical index of each linked todo to the file it links to.
+	Children map[int]*LinkNode
+}
+
+// BuildLinkTree parses rootPath and recursively follows links, resolved with
+// resolver, up to maxDepth levels below the root. Cycles are detected by
+// comparing absolute paths against the chain of ancestors, so a file linked
+// from two places appears under both, but a file never appears inside itself.
+// Each file is parsed once and its nodes share the same *TodoFile.
+func BuildLinkTree(rootPath string, maxDepth int, resolver *LinkResolver) *LinkNode {
+	return buildLinkNode(rootPath, maxDepth, resolver, map[string]bool{}, linkFileCache{})
+}
+
+// linkFileCache memoizes parsed files by absolute path while building a tree.
+type linkFileCache map[string]parsedLinkFile
+
+// parsedLinkFile is the result of parsing one file in the link tree.
+type parsedLinkFile struct {
+	file *TodoFile
+	err  error
+}
+
+// buildLinkNode builds the node for path; ancestors holds the absolute paths
+// of the files above it and is restored before returning.
+func buildLinkNode(path string, depthRemaining int, resolver *LinkResolver, ancestors map[string]bool, cache linkFileCache) *LinkNode {
+	node := &LinkNode{Path: path, Children: map[int]*LinkNode{}}
+
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		absolutePath = filepath.Clean(path)
+	}
+	if ancestors[absolutePath] {
+		node.Cycle = true
+		return node
+	}
+
+	parsed, ok := cache[absolutePath]
+	if !ok {
+		parsed.file, parsed.err = ParseFile(path)
+		cache[absolutePath] = parsed
+	}
+	node.File, node.Err = parsed.file, parsed.err
+	if node.Err != nil || depthRemaining <= 0 {
+		return node
+	}
+
+	ancestors[absolutePath] = true
+	defer delete(ancestors, absolutePath)
+
+	for i := 0; i < node.File.TodoCount(); i++ {
+		childPath := resolver.Resolve(path, node.File.GetTodo(i))
+		if childPath == "" {
+			continue
+		}
+		node.Children[i] = buildLinkNode(childPath, depthRemaining-1, resolver, ancestors, cache)
+	}
+	return node
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dueDateLayout is the date format used in due: tokens.
+const dueDateLayout = "2006-01-02"
+
+var (
+	// labelRegex matches a #tag, @context, or +project preceded by
+	// whitespace or the start of the text. Contexts and projects start with
+	// a letter so "+1" and "@2pm" are left alone.
+	labelRegex = regexp.MustCompile(`(?:^|\s)(#[\p{L}\p{N}_/-]+|[@+][\p{L}_][\p{L}\p{N}_/-]*)`)
+	// wordLabelRegex matches a word that is a label and nothing else.
+	wordLabelRegex = regexp.MustCompile(`^(?:#[\p{L}\p{N}_/-]+|[@+][\p{L}_][\p{L}\p{N}_/-]*)$`)
+	// dueRegex matches a due:YYYY-MM-DD token or an Obsidian Tasks
+	// "📅 YYYY-MM-DD" date, with the whitespace before it.
+	dueRegex = regexp.MustCompile(`(?:^|\s+)(due:|📅\s*)(\d{4}-\d{2}-\d{2})\b`)
+	// dateOffsetRegex matches a relative date such as +3d, +2w, or +1m.
+	dateOffsetRegex = regexp.MustCompile(`^\+(\d+)([dwm])$`)
+)
+
+// Labels returns the #tags, @contexts, and +projects in the todo's text,
+// with their sigils, in order.
+func (item TodoItem) Labels() []string {
+	var labels []string
+	for _, match := range labelRegex.FindAllStringSubmatch(item.Text, -1) {
+		labels = append(labels, match[1])
+	}
+	return labels
+}
+
+// labelsWithSigil returns the labels starting with sigil, without it.
+func (item TodoItem) labelsWithSigil(sigil byte) []string {
+	var names []string
+	for _, label := range item.Labels() {
+		if label[0] == sigil {
+			names = append(names, label[1:])
+		}
+	}
+	return names
+}
+
+// Tags returns the #tags in the todo's text, without the #, in order.
+func (item TodoItem) Tags() []string {
+	return item.labelsWithSigil('#')
+}
+
+// Contexts returns the @contexts in the todo's text, without the @, in order.
+func (item TodoItem) Contexts() []string {
+	return item.labelsWithSigil('@')
+}
+
+// Projects returns the +projects in the todo's text, without the +, in order.
+func (item TodoItem) Projects() []string {
+	return item.labelsWithSigil('+')
+}
+
+// HasTag reports whether the todo carries tag, ignoring case. A tag starting
+// with @ or + names a context or project; anything else is a #tag, with or
+// without the #.
+func (item TodoItem) HasTag(tag string) bool {
+	if !strings.HasPrefix(tag, "@") && !strings.HasPrefix(tag, "+") && !strings.HasPrefix(tag, "#") {
+		tag = "#" + tag
+	}
+	for _, label := range item.Labels() {
+		if strings.EqualFold(label, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripTrailingLabels removes the #tags, @contexts, and +projects written
+// as separate words at the end of text. Labels in the first word or joined to
+// other text, like the "#" in "Project #1.md", are kept.
+func stripTrailingLabels(text string) string {
+	text = strings.TrimSpace(text)
+	for {
+		i := strings.LastIndexAny(text, " \t")
+		if i < 0 || !wordLabelRegex.MatchString(text[i+1:]) {
+			return text
+		}
+		text = strings.TrimSpace(text[:i])
+	}
+}
+
+// LabelCount is the number of todos carrying a label.
+type LabelCount struct {
+	// Label is the #tag, @context, or +project as first written.
+	Label string
+	Count int
+}
+
+// CountLabels counts the todos carrying each label, ignoring case, most
+// used first and then alphabetically. A todo repeating a label counts once.
+func CountLabels(items []TodoItem) []LabelCount {
+	var counts []LabelCount
+	indexes := map[string]int{}
+	for _, item := range items {
+		seen := map[string]bool{}
+		for _, label := range item.Labels() {
+			key := strings.ToLower(label)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if i, ok := indexes[key]; ok {
+				counts[i].Count++
+				continue
+			}
+			indexes[key] = len(counts)
+			counts = append(counts, LabelCount{Label: label, Count: 1})
+		}
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return strings.ToLower(counts[i].Label) < strings.ToLower(counts[j].Label)
+	})
+	return counts
+}
+
+// DueDate returns the date from a due:YYYY-MM-DD token or a
+// "📅 YYYY-MM-DD" date, in local time. The bool is false when there is no
+// valid due date.
+func (item TodoItem) DueDate() (time.Time, bool) {
+	match := dueRegex.FindStringSubmatch(item.Text)
+	if match == nil {
+		return time.Time{}, false
+	}
+	due, err := time.ParseInLocation(dueDateLayout, match[2], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+// WithDueDate returns the todo with its due date set to due, keeping the
+// syntax already in the text or appending a due: token (a 📅 date when the
+// todo repeats with 🔁). A zero due removes the due date.
+func (item TodoItem) WithDueDate(due time.Time) TodoItem {
+	loc := dueRegex.FindStringSubmatchIndex(item.Text)
+	switch {
+	case due.IsZero() && loc == nil:
+		return item
+	case due.IsZero():
+		item.Text = strings.TrimSpace(item.Text[:loc[0]] + item.Text[loc[1]:])
+	case loc == nil:
+		prefix := " due:"
+		if strings.Contains(item.Text, "🔁") {
+			prefix = " 📅 "
+		}
+		item.Text = strings.TrimSpace(item.Text + prefix + due.Format(dueDateLayout))
+	default:
+		item.Text = item.Text[:loc[4]] + due.Format(dueDateLayout) + item.Text[loc[5]:]
+	}
+	return item
+}
+
+// stripMetadata removes the due date, repeat rule, completion and created
+// dates, and priority marker from text.
+func stripMetadata(text string) string {
+	for _, re := range []*regexp.Regexp{dueRegex, recurrenceTokenRegex, completionRegex, createdRegex} {
+		if re.MatchString(text) {
+			text = strings.TrimSpace(re.ReplaceAllString(text, ""))
+		}
+	}
+	return stripPriority(text)
+}
+
+// StartOfDay returns midnight at the start of t's day in local time.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// ParseDate parses a date typed by the user relative to now: YYYY-MM-DD,
+// today, tomorrow, yesterday, an offset such as +3d, +2w, or +1m, or a
+// weekday name (mon or monday), meaning the next such day after today.
+func ParseDate(value string, now time.Time) (time.Time, error) {
+	today := StartOfDay(now)
+	input := value
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	if match := dateOffsetRegex.FindStringSubmatch(value); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "d":
+			return today.AddDate(0, 0, count), nil
+		case "w":
+			return today.AddDate(0, 0, 7*count), nil
+		default:
+			return addMonths(today, count), nil
+		}
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || value == name[:3] {
+			days := (int(day)-int(today.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days), nil
+		}
+	}
+	date, err := time.ParseInLocation(dueDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD, today, tomorrow, +3d, +2w, +1m, or a weekday)", input)
+	}
+	return date, nil
+}
+
+// addMonths adds months to t, clamping the day to the end of a shorter
+// month so Jan 31 plus one month is the last day of February.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
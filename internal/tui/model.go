@@ -8,8 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const (
@@ -38,6 +40,28 @@ const (
 	ModeCreating
 	// ModeRearrange is active when reordering todos with j/k swaps.
 	ModeRearrange
+	// ModeSelect is active when extending a range of todos to yank.
+	ModeSelect
+	// ModeHelp is active while the full-screen keybinding overlay is shown.
+	ModeHelp
+	// ModeTree is active while browsing the outline of all linked files.
+	ModeTree
+	// ModeSwitcher is active while fuzzy-searching for a file to open.
+	ModeSwitcher
+	// ModeNewLinked is active when entering the name of a new linked file.
+	ModeNewLinked
+	// ModeBacklinks is active while listing the items that link to the current file.
+	ModeBacklinks
+	// ModeQueryInput is active when typing a query.
+	ModeQueryInput
+	// ModeQuery is active while browsing query results across linked files.
+	ModeQuery
+	// ModeQueryEdit is active when inline-editing a query result's text.
+	ModeQueryEdit
+	// ModeDueInput is active when typing a due date for the current todo.
+	ModeDueInput
+	// ModeTagPicker is active while choosing the labels to filter the list by.
+	ModeTagPicker
 )
 
 // navigationEntry stores position information for back-navigation.
@@ -55,6 +79,59 @@ type model struct {
 	headerIcon    string
 	navStack      []navigationEntry
 	statusMessage string
+	noticeMessage string
+	config        Config
+	keys          keyMap
+	register      []TodoItem
+	selectAnchor  int
+	helpScroll    int
+	width         int
+	height        int
+
+	// pendingAncestorJump is set after the breadcrumb key until a digit picks an ancestor.
+	pendingAncestorJump bool
+
+	showPreview bool
+	previewPath string
+	previewFile *TodoFile
+	previewErr  error
+
+	treeRoot     *LinkNode
+	treeExpanded map[string]bool
+	treeCursor   int
+
+	linkProgress map[string]Progress
+	// seenProgress keeps the last progress seen for every linked file across
+	// file switches, so auto-check can tell when one newly becomes complete.
+	seenProgress map[string]Progress
+	resolver     *LinkResolver
+
+	switcherRoot     string
+	switcherFiles    []string
+	switcherMatches  []string
+	switcherCursor   int
+	switcherIndexing bool
+
+	backlinks       []Backlink
+	backlinksRoot   string
+	backlinksCursor int
+
+	query        Query
+	queryResults []QueryResult
+	queryCursor  int
+
+	// tagFilter lists the labels the list is filtered to; empty shows all.
+	tagFilter []string
+	tagCounts []LabelCount
+	tagPicked map[string]bool
+	tagCursor int
+
+	// now returns the current time; the header date, due date styles, and
+	// new file templates use it.
+	now func() time.Time
+
+	// osc52 is a clipboard escape sequence to emit with the next frame.
+	osc52 string
 }
 
 // switchFileMsg is returned by loadFileCmd after attempting to parse a file.
@@ -62,6 +139,9 @@ type switchFileMsg struct {
 	newFile       *TodoFile
 	restoreCursor int // -1 = start at 0 (forward nav), >= 0 = restore (back nav)
 	err           error
+	replaceStack  bool              // install navStack on success (jumps)
+	navStack      []navigationEntry // stack to install when replaceStack is set
+	status        string            // status line to show once the file is loaded
 }
 
 // loadFileCmd returns a tea.Cmd that parses a file and sends a switchFileMsg.
@@ -72,17 +152,39 @@ func loadFileCmd(path string, restoreCursor int) tea.Cmd {
 	}
 }
 
+// jumpToFileCmd returns a tea.Cmd that parses a file and, on success, replaces
+// the navigation stack with stack and places the cursor at cursor.
+// On failure the current file and stack are left untouched.
+func jumpToFileCmd(path string, cursor int, stack []navigationEntry) tea.Cmd {
+	return func() tea.Msg {
+		todoFile, err := ParseFile(path)
+		return switchFileMsg{
+			newFile:       todoFile,
+			restoreCursor: cursor,
+			err:           err,
+			replaceStack:  true,
+			navStack:      stack,
+		}
+	}
+}
+
 // fileBasenameWithoutExtension returns the filename without its directory or extension.
 func fileBasenameWithoutExtension(filePath string) string {
 	baseName := filepath.Base(filePath)
 	return strings.TrimSuffix(baseName, filepath.Ext(baseName))
 }
 
-func initialModel(todoFile *TodoFile, navigationStack []navigationEntry) model {
+func initialModel(todoFile *TodoFile, navigationStack []navigationEntry, config Config, keys keyMap) model {
 	textInput := textinput.New()
 	textInput.CharLimit = textInputCharLimit
 	textInput.Width = textInputWidth
 
+	todoFile.Options = config.FileOptions()
+	rootFilePath := todoFile.Path
+	if len(navigationStack) > 0 {
+		rootFilePath = navigationStack[0].FilePath
+	}
+
 	return model{
 		file:       todoFile,
 		cursor:     0,
@@ -90,12 +192,16 @@ func initialModel(todoFile *TodoFile, navigationStack []navigationEntry) model {
 		textInput:  textInput,
 		headerIcon: headerIcons[rand.IntN(len(headerIcons))],
 		navStack:   navigationStack,
+		config:     config,
+		keys:       keys,
+		resolver:   NewLinkResolver(config.VaultRootFor(rootFilePath)),
+		now:        time.Now,
 	}
 }
 
 // Run parses the todo file at filePath and starts the TUI.
 // returnStack provides file paths for back-navigation (each starts at cursor 0).
-func Run(filePath string, returnStack []string) error {
+func Run(filePath string, returnStack []string, config Config) error {
 	todoFile, err := ParseFile(filePath)
 	if err != nil {
 		return fmt.Errorf("loading file: %w", err)
@@ -109,8 +215,16 @@ func Run(filePath string, returnStack []string) error {
 		})
 	}
 
-	m := initialModel(todoFile, navigationStack)
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	keys, err := newKeyMap(config.Keys)
+	if err != nil {
+		return fmt.Errorf("loading keymap: %w", err)
+	}
+	if err := applyTheme(config.Theme, config.Colors); err != nil {
+		return fmt.Errorf("loading theme: %w", err)
+	}
+
+	m := initialModel(todoFile, navigationStack, config, keys)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		return err
 	}
@@ -118,20 +232,56 @@ func Run(filePath string, returnStack []string) error {
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	return loadProgressCmd(m.file, m.resolver)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.handleMsg(msg)
+	next, previewCmd := next.(model).snapToVisible().syncPreview()
+	return next, tea.Batch(cmd, previewCmd)
+}
+
+// handleMsg dispatches a message to the handler for its type and the current mode.
+func (m model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case switchFileMsg:
 		return m.handleSwitchFile(msg)
-	case tea.KeyMsg:
-		// Clear status message on any keypress
-		if m.statusMessage != "" {
-			m.statusMessage = ""
+	case filesIndexedMsg:
+		return m.handleFilesIndexed(msg)
+	case treeBuiltMsg:
+		return m.handleTreeBuilt(msg)
+	case backlinksFoundMsg:
+		return m.handleBacklinksFound(msg)
+	case queryResultsMsg:
+		return m.handleQueryResults(msg)
+	case progressLoadedMsg:
+		return m.handleProgressLoaded(msg)
+	case previewLoadedMsg:
+		return m.handlePreviewLoaded(msg)
+	case editorFinishedMsg:
+		return m.handleEditorFinished(msg)
+	case clipboardErrorMsg:
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	case osc52Msg:
+		return m.handleOSC52(msg)
+	case osc52ClearMsg:
+		if m.osc52 == msg.sequence {
+			m.osc52 = ""
 		}
+		return m, nil
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		// Clear status messages on any keypress
+		m.statusMessage = ""
+		m.noticeMessage = ""
 
-		if msg.String() == "ctrl+c" {
+		if key.Matches(msg, m.keys.ForceQuit) {
 			return m, tea.Quit
 		}
 
@@ -144,6 +294,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateCreating(msg)
 		case ModeRearrange:
 			return m.updateRearrange(msg)
+		case ModeSelect:
+			return m.updateSelect(msg)
+		case ModeHelp:
+			return m.updateHelp(msg)
+		case ModeTree:
+			return m.updateTree(msg)
+		case ModeSwitcher:
+			return m.updateSwitcher(msg)
+		case ModeNewLinked:
+			return m.updateNewLinked(msg)
+		case ModeBacklinks:
+			return m.updateBacklinks(msg)
+		case ModeQueryInput:
+			return m.updateQueryInput(msg)
+		case ModeQuery:
+			return m.updateQuery(msg)
+		case ModeQueryEdit:
+			return m.updateQueryEdit(msg)
+		case ModeDueInput:
+			return m.updateDueInput(msg)
+		case ModeTagPicker:
+			return m.updateTagPicker(msg)
 		}
 	}
 	return m, nil
@@ -160,7 +332,13 @@ func (m model) handleSwitchFile(msg switchFileMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	previousPath := m.file.Path
 	m.file = msg.newFile
+	m.file.Options = m.config.FileOptions()
+	m = m.clearTagFilterOnSwitch(previousPath)
+	if msg.replaceStack {
+		m.navStack = msg.navStack
+	}
 	if msg.restoreCursor >= 0 {
 		m.cursor = msg.restoreCursor
 	} else {
@@ -176,8 +354,16 @@ func (m model) handleSwitchFile(msg switchFileMsg) (tea.Model, tea.Cmd) {
 
 	m.mode = ModeNormal
 	m.pendingDelete = false
-	m.statusMessage = ""
-	return m, nil
+	m.statusMessage = msg.status
+	// Force the preview to reload in case the linked file changed.
+	m.previewPath = ""
+	m.linkProgress = nil
+	// Opening a linked file starts its next round of work, so it counts as
+	// incomplete until a later load sees it complete again.
+	if _, ok := m.seenProgress[m.file.Path]; ok {
+		m.seenProgress[m.file.Path] = Progress{}
+	}
+	return m, loadProgressCmd(m.file, m.resolver)
 }
 
 // startTextInput sets up the text input with a value and focuses it.
@@ -192,7 +378,7 @@ func (m model) startTextInput(value string) (model, tea.Cmd) {
 
 func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.pendingDelete {
-		if msg.String() == "d" {
+		if key.Matches(msg, m.keys.Delete) {
 			m.file.DeleteTodo(m.cursor)
 			if err := m.file.Save(); err != nil {
 				m.statusMessage = fmt.Sprintf("Error saving: %v", err)
@@ -205,66 +391,188 @@ func (m model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.pendingDelete = false
 	}
+	if m.pendingAncestorJump {
+		return m.updateAncestorJump(msg)
+	}
 
-	switch msg.String() {
-	case "q", "esc":
+	switch {
+	case key.Matches(msg, m.keys.Quit):
 		if len(m.navStack) > 0 {
 			entry := m.navStack[len(m.navStack)-1]
 			m.navStack = m.navStack[:len(m.navStack)-1]
 			return m, loadFileCmd(entry.FilePath, entry.CursorPosition)
 		}
 		return m, tea.Quit
-	case "j", "down":
-		if m.cursor < m.file.TodoCount()-1 {
-			m.cursor++
-		}
-	case "k", "up":
-		if m.cursor > 0 {
-			m.cursor--
-		}
-	case " ", "enter":
+	case key.Matches(msg, m.keys.Down):
+		m.cursor = m.stepCursor(1)
+	case key.Matches(msg, m.keys.Up):
+		m.cursor = m.stepCursor(-1)
+	case key.Matches(msg, m.keys.Toggle):
 		if m.file.TodoCount() > 0 {
-			item := m.file.GetTodo(m.cursor)
-			linkedPath := item.LinkedPath()
-			if linkedPath != "" {
-				return m.navigateToLinkedFile(linkedPath)
-			}
-			m.file.ToggleTodo(m.cursor)
-			if err := m.file.Save(); err != nil {
-				m.statusMessage = fmt.Sprintf("Error saving: %v", err)
-			}
+			return m.toggleOrOpen()
 		}
-	case "x":
+	case key.Matches(msg, m.keys.ToggleOnly):
 		if m.file.TodoCount() > 0 {
-			m.file.ToggleTodo(m.cursor)
-			if err := m.file.Save(); err != nil {
-				m.statusMessage = fmt.Sprintf("Error saving: %v", err)
-			}
+			return m.toggleCurrent()
 		}
-	case "e":
+	case key.Matches(msg, m.keys.Edit):
 		if m.file.TodoCount() > 0 {
 			m.mode = ModeEditing
 			item := m.file.GetTodo(m.cursor)
 			return m.startTextInput(item.Text)
 		}
-	case "c":
+	case key.Matches(msg, m.keys.EditExternal):
+		if m.file.TodoCount() > 0 {
+			return m.openItemInEditor()
+		}
+	case key.Matches(msg, m.keys.OpenFile):
+		return m.openFileInEditor()
+	case key.Matches(msg, m.keys.Create):
 		m.mode = ModeCreating
 		return m.startTextInput("")
-	case "r":
-		if m.file.TodoCount() > 0 {
+	case key.Matches(msg, m.keys.Rearrange):
+		if m.hidesTodos() {
+			m.noticeMessage = "Clear the tag filter to rearrange"
+		} else if m.file.TodoCount() > 0 {
 			m.mode = ModeRearrange
 		}
-	case "d":
+	case key.Matches(msg, m.keys.Delete):
 		if m.file.TodoCount() > 0 {
 			m.pendingDelete = true
 		}
+	case key.Matches(msg, m.keys.Yank):
+		if m.file.TodoCount() > 0 {
+			return m.yankRange(m.cursor, m.cursor)
+		}
+	case key.Matches(msg, m.keys.Select):
+		if m.hidesTodos() {
+			m.noticeMessage = "Clear the tag filter to select"
+		} else if m.file.TodoCount() > 0 {
+			m.mode = ModeSelect
+			m.selectAnchor = m.cursor
+		}
+	case key.Matches(msg, m.keys.Preview):
+		m.showPreview = !m.showPreview
+		m.previewPath = ""
+	case key.Matches(msg, m.keys.Breadcrumb):
+		if len(m.navStack) > 0 {
+			m.pendingAncestorJump = true
+		}
+	case key.Matches(msg, m.keys.Tree):
+		return m, buildTreeCmd(m.treeRootPath(), m.resolver)
+	case key.Matches(msg, m.keys.Switcher):
+		return m.openSwitcher()
+	case key.Matches(msg, m.keys.NewLinked):
+		m.mode = ModeNewLinked
+		return m.startTextInput("")
+	case key.Matches(msg, m.keys.Backlinks):
+		return m, findBacklinksCmd(m.backlinkSearchRoots(), m.file.Path, m.resolver)
+	case key.Matches(msg, m.keys.Query):
+		m.mode = ModeQueryInput
+		return m.startTextInput(m.query.Source)
+	case key.Matches(msg, m.keys.Due):
+		if m.file.TodoCount() > 0 {
+			return m.startDueInput()
+		}
+	case key.Matches(msg, m.keys.Raise):
+		return m.shiftPriority(1)
+	case key.Matches(msg, m.keys.Lower):
+		return m.shiftPriority(-1)
+	case key.Matches(msg, m.keys.Sort):
+		return m.sortBySection()
+	case key.Matches(msg, m.keys.Tags):
+		return m.openTagPicker()
+	case key.Matches(msg, m.keys.Help):
+		m.mode = ModeHelp
+		m.helpScroll = 0
+	case key.Matches(msg, m.keys.Paste):
+		return m.paste(false)
+	case key.Matches(msg, m.keys.PasteAbove):
+		return m.paste(true)
+	}
+	return m, nil
+}
+
+// toggleOrOpen navigates into the current item if it is linked, otherwise toggles it.
+func (m model) toggleOrOpen() (tea.Model, tea.Cmd) {
+	item := m.file.GetTodo(m.cursor)
+	if item.LinkedPath() != "" {
+		return m.navigateToLinkedFile(item)
+	}
+	return m.toggleCurrent()
+}
+
+// toggleCurrent flips the checkbox of the current item and saves.
+func (m model) toggleCurrent() (tea.Model, tea.Cmd) {
+	if nextIdx := m.file.ToggleTodo(m.cursor); nextIdx >= 0 {
+		m.noticeMessage = m.recurrenceNotice(m.file.GetTodo(nextIdx))
+	}
+	if err := m.file.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
 	}
 	return m, nil
 }
 
-// navigateToLinkedFile resolves a linked path and initiates navigation.
-func (m model) navigateToLinkedFile(linkedPath string) (tea.Model, tea.Cmd) {
-	resolvedPath := ResolveLinkedPath(m.file.Path, linkedPath)
+// selectionBounds returns the inclusive logical range between the select anchor and the cursor.
+func (m model) selectionBounds() (int, int) {
+	if m.selectAnchor < m.cursor {
+		return m.selectAnchor, m.cursor
+	}
+	return m.cursor, m.selectAnchor
+}
+
+// yankRange copies todos first..last (inclusive) into the register and,
+// if configured, the clipboard.
+func (m model) yankRange(first, last int) (tea.Model, tea.Cmd) {
+	m.register = nil
+	for i := first; i <= last; i++ {
+		m.register = append(m.register, m.file.GetTodo(i))
+	}
+	if len(m.register) == 1 {
+		m.noticeMessage = "Yanked 1 item"
+	} else {
+		m.noticeMessage = fmt.Sprintf("Yanked %d items", len(m.register))
+	}
+	return m, copyToClipboardCmd(m.config.Clipboard, formatRegister(m.register))
+}
+
+// paste inserts the register below the cursor, or above it when above is true.
+// The cursor moves to the first pasted item.
+func (m model) paste(above bool) (tea.Model, tea.Cmd) {
+	if len(m.register) == 0 {
+		m.statusMessage = "Error: nothing to paste"
+		return m, nil
+	}
+
+	var firstPasted int
+	switch {
+	case m.file.TodoCount() == 0:
+		for _, item := range m.register {
+			m.file.InsertTodo(-1, item)
+		}
+		firstPasted = 0
+	case above:
+		for offset, item := range m.register {
+			m.file.InsertTodoBefore(m.cursor+offset, item)
+		}
+		firstPasted = m.cursor
+	default:
+		for offset, item := range m.register {
+			m.file.InsertTodo(m.cursor+offset, item)
+		}
+		firstPasted = m.cursor + 1
+	}
+
+	if err := m.file.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+	}
+	m.cursor = firstPasted
+	return m, loadProgressCmd(m.file, m.resolver)
+}
+
+// navigateToLinkedFile resolves a linked item's target and initiates navigation.
+func (m model) navigateToLinkedFile(item TodoItem) (tea.Model, tea.Cmd) {
+	resolvedPath := m.resolver.Resolve(m.file.Path, item)
 
 	// Check for self-reference
 	currentAbsolutePath, err := filepath.Abs(m.file.Path)
@@ -298,16 +606,16 @@ func (m model) navigateToLinkedFile(linkedPath string) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
 		m.file.SetTodoText(m.cursor, m.textInput.Value())
 		if err := m.file.Save(); err != nil {
 			m.statusMessage = fmt.Sprintf("Error saving: %v", err)
 		}
 		m.textInput.Blur()
 		m.mode = ModeNormal
-		return m, nil
-	case "esc":
+		return m, loadProgressCmd(m.file, m.resolver)
+	case key.Matches(msg, m.keys.Cancel):
 		m.textInput.Blur()
 		m.mode = ModeNormal
 		return m, nil
@@ -319,11 +627,11 @@ func (m model) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateCreating(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
 		text := strings.TrimSpace(m.textInput.Value())
 		if text != "" {
-			newItem := TodoItem{Text: text, Checked: false}
+			newItem := m.file.StampCreated(TodoItem{Text: text, Checked: false}, m.now())
 			m.file.InsertTodo(m.cursor, newItem)
 			if err := m.file.Save(); err != nil {
 				m.statusMessage = fmt.Sprintf("Error saving: %v", err)
@@ -332,8 +640,8 @@ func (m model) updateCreating(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.textInput.Blur()
 		m.mode = ModeNormal
-		return m, nil
-	case "esc":
+		return m, loadProgressCmd(m.file, m.resolver)
+	case key.Matches(msg, m.keys.Cancel):
 		m.textInput.Blur()
 		m.mode = ModeNormal
 		return m, nil
@@ -345,8 +653,8 @@ func (m model) updateCreating(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m model) updateRearrange(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "j", "down":
+	switch {
+	case key.Matches(msg, m.keys.Down):
 		if m.cursor < m.file.TodoCount()-1 {
 			m.file.SwapTodos(m.cursor, m.cursor+1)
 			if err := m.file.Save(); err != nil {
@@ -354,7 +662,7 @@ func (m model) updateRearrange(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			m.cursor++
 		}
-	case "k", "up":
+	case key.Matches(msg, m.keys.Up):
 		if m.cursor > 0 {
 			m.file.SwapTodos(m.cursor, m.cursor-1)
 			if err := m.file.Save(); err != nil {
@@ -362,24 +670,36 @@ func (m model) updateRearrange(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			m.cursor--
 		}
-	case "r", "esc":
+	case key.Matches(msg, m.keys.Rearrange, m.keys.Cancel):
 		m.mode = ModeNormal
 	}
 	return m, nil
 }
 
-// renderHeader builds the header string with repeated depth icons, file basename, and date.
-func (m model) renderHeader() string {
-	navigationDepth := len(m.navStack)
-	repeatedIcons := strings.Repeat(m.headerIcon, navigationDepth+1)
-	currentFileBasename := fileBasenameWithoutExtension(m.file.Path)
-	currentDateFormatted := time.Now().Format("Jan 2, 2006")
-	headerText := fmt.Sprintf("%s %s [%s]", repeatedIcons, currentFileBasename, currentDateFormatted)
-	return titleStyle.Render(headerText)
+func (m model) updateSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.cursor < m.file.TodoCount()-1 {
+			m.cursor++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(msg, m.keys.Yank):
+		first, last := m.selectionBounds()
+		m.mode = ModeNormal
+		m.cursor = first
+		return m.yankRange(first, last)
+	case key.Matches(msg, m.keys.Select, m.keys.Cancel):
+		m.mode = ModeNormal
+	}
+	return m, nil
 }
 
 func (m model) View() string {
 	var b strings.Builder
+	b.WriteString(m.osc52)
 
 	// Header
 	b.WriteString(m.renderHeader())
@@ -390,13 +710,101 @@ func (m model) View() string {
 		b.WriteString(errorStyle.Render("  " + m.statusMessage))
 		b.WriteString("\n")
 	}
+	if m.noticeMessage != "" {
+		b.WriteString(noticeStyle.Render("  " + m.noticeMessage))
+		b.WriteString("\n")
+	}
+
+	if m.mode == ModeHelp {
+		b.WriteString(m.renderHelpOverlay())
+		b.WriteString("\n\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	if m.mode == ModeSwitcher {
+		b.WriteString(m.renderSwitcher())
+		b.WriteString("\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	if m.mode == ModeQuery || m.mode == ModeQueryEdit {
+		b.WriteString(m.renderQuery())
+		b.WriteString("\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	if m.mode == ModeTagPicker {
+		b.WriteString(m.renderTagPicker())
+		b.WriteString("\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	if m.mode == ModeBacklinks {
+		b.WriteString(m.renderBacklinks())
+		b.WriteString("\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	if m.mode == ModeTree {
+		b.WriteString(m.renderTree())
+		b.WriteString("\n")
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	list := m.renderList()
+	if m.showPreview {
+		list = m.withPreviewPane(list)
+	}
+	b.WriteString(list)
+
+	if m.mode == ModeNewLinked {
+		b.WriteString("\n")
+		b.WriteString(cursorStyle.Render("  New linked list: ") + m.textInput.View())
+		b.WriteString("\n")
+	}
+	if m.mode == ModeQueryInput {
+		b.WriteString("\n")
+		b.WriteString(cursorStyle.Render("  Query: ") + m.textInput.View())
+		b.WriteString("\n")
+	}
+	if m.mode == ModeDueInput {
+		b.WriteString("\n")
+		b.WriteString(cursorStyle.Render("  Due: ") + m.textInput.View())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderHelp())
+
+	return b.String()
+}
+
+// renderList renders the todo rows (or the empty-file hint), one per line.
+func (m model) renderList() string {
+	var b strings.Builder
 
 	if m.file.TodoCount() == 0 && m.mode != ModeCreating {
-		b.WriteString("\n  No todos. Press 'c' to create one.\n")
+		b.WriteString(fmt.Sprintf("\n  No todos. Press '%s' to create one.\n", firstKeyLabel(m.keys.Create)))
+	}
+	if len(m.tagFilter) > 0 {
+		b.WriteString(m.renderTagFilter())
+		b.WriteString("\n")
+		if len(m.visibleTodos()) == 0 && m.mode != ModeCreating {
+			b.WriteString(fmt.Sprintf("\n  No todos match. Press '%s' to change the filter.\n", firstKeyLabel(m.keys.Tags)))
+		}
 	}
 
-	// Todo list
-	for i := 0; i < m.file.TodoCount(); i++ {
+	// Todo list, scrolled to keep the cursor on screen
+	visible := m.visibleTodos()
+	start := m.listScroll()
+	end := min(len(visible), start+m.listRows())
+	for _, i := range visible[start:end] {
 		item := m.file.GetTodo(i)
 		isCursor := i == m.cursor
 
@@ -418,9 +826,6 @@ func (m model) View() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString(m.renderHelp())
-
 	return b.String()
 }
 
@@ -431,12 +836,21 @@ func (m model) renderTodoLine(idx int, item TodoItem, isCursor bool) string {
 	}
 
 	numStr := priorityStyle.Render(m.fmtLineNum(idx+1, m.file.TodoCount()))
+	text := m.displayText(item)
+	badge := priorityBadge(item)
+	dates := m.dueBadge(item) + repeatBadge(item) + m.doneBadge(item)
 
 	if isCursor && m.pendingDelete {
-		return deleteStyle.Render(cursor) + numStr + deleteStyle.Render(item.Text)
+		return deleteStyle.Render(cursor) + numStr + badge + deleteStyle.Render(text) + dates
 	}
 	if isCursor && m.mode == ModeRearrange {
-		return rearrangeStyle.Render(cursor) + numStr + rearrangeStyle.Render(item.Text)
+		return rearrangeStyle.Render(cursor) + numStr + badge + rearrangeStyle.Render(text) + dates
+	}
+	if m.mode == ModeSelect {
+		first, last := m.selectionBounds()
+		if idx >= first && idx <= last {
+			return selectStyle.Render(cursor) + numStr + badge + selectStyle.Render(text) + dates
+		}
 	}
 	if isCursor {
 		textStyle := cursorStyle
@@ -446,18 +860,18 @@ func (m model) renderTodoLine(idx int, item TodoItem, isCursor bool) string {
 		if item.IsLinkedTodo() {
 			textStyle = textStyle.Underline(true)
 		}
-		return textStyle.Render(cursor) + numStr + textStyle.Render(item.Text)
+		return textStyle.Render(cursor) + numStr + badge + styleLabels(text, textStyle) + dates
 	}
 	if item.IsLinkedTodo() {
 		if item.Checked {
-			return cursor + numStr + linkStyle.Strikethrough(true).Render(item.Text)
+			return cursor + numStr + badge + styleLabels(text, linkStyle.Strikethrough(true)) + dates
 		}
-		return cursor + numStr + linkStyle.Render(item.Text)
+		return cursor + numStr + badge + styleLabels(text, linkStyle) + dates
 	}
 	if item.Checked {
-		return cursor + numStr + checkedStyle.Render(item.Text)
+		return cursor + numStr + badge + styleLabels(text, checkedStyle) + dates
 	}
-	return cursor + numStr + item.Text
+	return cursor + numStr + badge + styleLabels(text, lipgloss.NewStyle()) + dates
 }
 
 // fmtLineNum formats a 1-based line number right-aligned to the width
@@ -473,23 +887,108 @@ func (m model) renderInputLine(oneBasedIdx, totalItems int) string {
 	return cursorStyle.Render(" > ") + priorityStyle.Render(num) + m.textInput.View()
 }
 
+// renderHelp builds the footer from the active keymap for the current mode.
 func (m model) renderHelp() string {
+	keys := m.keys
 	switch m.mode {
 	case ModeNormal:
 		if m.pendingDelete {
-			return helpStyle.Render("  press d again to delete  |  any other key to cancel")
+			return helpStyle.Render(fmt.Sprintf("  press %s again to delete  |  any other key to cancel", firstKeyLabel(keys.Delete)))
+		}
+		if m.pendingAncestorJump {
+			return helpStyle.Render(fmt.Sprintf("  press 1-%d to jump to an ancestor  |  any other key to cancel", min(len(m.navStack), maxAncestorJump)))
 		}
-		quitOrBackLabel := "esc/q: quit"
+		quitOrBackDesc := "quit"
 		if len(m.navStack) > 0 {
-			quitOrBackLabel = "esc/q: back"
+			quitOrBackDesc = "back"
 		}
-		return helpStyle.Render("  j/k: navigate  space/enter: toggle/open  x: toggle  e: edit  c: create  r: rearrange  d: delete  " + quitOrBackLabel)
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "navigate"},
+			{keys.Toggle.Help().Key, "toggle/open"},
+			{keys.Help.Help().Key, "help"},
+			{keys.Quit.Help().Key, quitOrBackDesc},
+		}))
 	case ModeEditing:
-		return helpStyle.Render("  enter: save  esc: cancel")
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "save"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
 	case ModeCreating:
-		return helpStyle.Render("  enter: create  esc: cancel")
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "create"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
+	case ModeNewLinked:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "create, link, and open"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
 	case ModeRearrange:
-		return helpStyle.Render("  j/k: swap items  r/esc: done rearranging")
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "swap items"},
+			{pairLabel(keys.Rearrange, keys.Cancel), "done rearranging"},
+		}))
+	case ModeSelect:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "extend selection"},
+			{keys.Yank.Help().Key, "yank"},
+			{pairLabel(keys.Select, keys.Cancel), "cancel"},
+		}))
+	case ModeHelp:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "scroll"},
+			{pairLabel(keys.Help, keys.Cancel), "close"},
+		}))
+	case ModeSwitcher:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.NextMatch, keys.PrevMatch), "select"},
+			{keys.Confirm.Help().Key, "open/create"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
+	case ModeTree:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "navigate"},
+			{pairLabel(keys.Collapse, keys.Expand), "collapse/expand"},
+			{keys.Confirm.Help().Key, "open"},
+			{pairLabel(keys.Tree, keys.Cancel), "close"},
+		}))
+	case ModeQueryInput:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "search linked files"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
+	case ModeQuery:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "navigate"},
+			{keys.ToggleOnly.Help().Key, "toggle"},
+			{keys.Edit.Help().Key, "edit"},
+			{keys.Confirm.Help().Key, "open"},
+			{keys.Query.Help().Key, "refine"},
+			{keys.Quit.Help().Key, "close"},
+		}))
+	case ModeQueryEdit:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "save"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
+	case ModeTagPicker:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "navigate"},
+			{keys.ToggleOnly.Help().Key, "select"},
+			{keys.Confirm.Help().Key, "filter (none selected shows all)"},
+			{pairLabel(keys.Tags, keys.Cancel), "cancel"},
+		}))
+	case ModeDueInput:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{keys.Confirm.Help().Key, "set due date (YYYY-MM-DD, today, +3d, fri; empty clears)"},
+			{keys.Cancel.Help().Key, "cancel"},
+		}))
+	case ModeBacklinks:
+		return helpStyle.Render(renderHelpEntries([]helpEntry{
+			{pairLabel(keys.Down, keys.Up), "navigate"},
+			{keys.Confirm.Help().Key, "open"},
+			{pairLabel(keys.Backlinks, keys.Cancel), "close"},
+		}))
 	}
 	return ""
 }
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestModel writes content to a todo file in a temp dir and returns a
+// model on it, sized 80x24, with the clock fixed at noon on 2026-10-18.
+func newTestModel(t *testing.T, content string, config Config) model {
+	t.Helper()
+	return newTestModelAt(t, filepath.Join(t.TempDir(), "todo.md"), content, config)
+}
+
+// newTestModelAt is newTestModel with the todo file written to path.
+func newTestModelAt(t *testing.T, path, content string, config Config) model {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	todoFile, err := ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := newKeyMap(config.Keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel(todoFile, nil, config, keys)
+	m.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local) }
+	return update(m, tea.WindowSizeMsg{Width: 80, Height: 24})
+}
+
+// update sends msg to m and runs the commands it returns, feeding their
+// messages back in, until none are left.
+func update(m model, msg tea.Msg) model {
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		next, cmd := m.Update(queue[0])
+		m = next.(model)
+		queue = append(queue[1:], runTestCmd(cmd)...)
+	}
+	return m
+}
+
+// runTestCmd runs cmd and any batch it returns, collecting their messages.
+func runTestCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runTestCmd(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+// press sends each key to m in turn.
+func press(m model, keys ...string) model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		m = update(m, msg)
+	}
+	return m
+}
+
+func TestHandleEditorFinished_ShowsFileEditorError(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n", DefaultConfig())
+	m = update(m, editorFinishedMsg{err: errors.New("exit status 1"), filePath: m.file.Path})
+	if want := "Error: editor: exit status 1"; m.statusMessage != want {
+		t.Errorf("statusMessage = %q, want %q", m.statusMessage, want)
+	}
+}
+
+func TestHandleEditorFinished_ReloadsFile(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n", DefaultConfig())
+	if err := os.WriteFile(m.file.Path, []byte("- [ ] one\n- [ ] two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m = update(m, editorFinishedMsg{filePath: m.file.Path, todoIdx: 1})
+	if m.file.TodoCount() != 2 || m.cursor != 1 || m.statusMessage != "" {
+		t.Errorf("after reload: %d todos, cursor %d, status %q", m.file.TodoCount(), m.cursor, m.statusMessage)
+	}
+}
+
+func TestRenderList_EmptyHintUsesCreateKey(t *testing.T) {
+	config := DefaultConfig()
+	config.Keys = map[string][]string{"create": {"a"}}
+	m := newTestModel(t, "# Empty\n", config)
+	if view := m.View(); !strings.Contains(view, "Press 'a' to create one") {
+		t.Errorf("expected the remapped create key in the empty hint, got:\n%s", view)
+	}
+}
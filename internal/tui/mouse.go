@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listTop returns the screen row of the first todo line, matching the layout
+// produced by View: header, then optional status and notice lines.
+func (m model) listTop() int {
+	top := lipgloss.Height(m.renderHeader())
+	if m.statusMessage != "" {
+		top++
+	}
+	if m.noticeMessage != "" {
+		top++
+	}
+	if len(m.tagFilter) > 0 {
+		top++
+	}
+	return top
+}
+
+// listRows returns how many todo rows fit between listTop and the footer:
+// the help line, a blank line above it, and any prompt below the list. A
+// zero height (size not yet known) fits every row.
+func (m model) listRows() int {
+	if m.height == 0 {
+		return m.file.TodoCount()
+	}
+	rows := m.height - m.listTop() - 1 - lipgloss.Height(m.renderHelp())
+	switch m.mode {
+	case ModeCreating:
+		rows--
+	case ModeNewLinked, ModeQueryInput, ModeDueInput:
+		rows -= 2
+	}
+	return max(1, rows)
+}
+
+// listScroll returns how many visible todos are scrolled off the top of the
+// list, keeping the cursor on screen.
+func (m model) listScroll() int {
+	return max(0, slices.Index(m.visibleTodos(), m.cursor)-m.listRows()+1)
+}
+
+// gutterWidth returns the width of the cursor marker and line number columns,
+// which act as the clickable checkbox column.
+func (m model) gutterWidth() int {
+	return len(" > ") + len(m.fmtLineNum(m.file.TodoCount(), m.file.TodoCount()))
+}
+
+// todoAtRow maps a screen row to a logical todo index, or -1 if none is there.
+// Rows skip todos hidden by the tag filter and those scrolled off the top.
+func (m model) todoAtRow(row int) int {
+	visible := m.visibleTodos()
+	offset := row - m.listTop()
+	visibleIdx := offset + m.listScroll()
+	if offset < 0 || offset >= m.listRows() || visibleIdx >= len(visible) {
+		return -1
+	}
+	return visible[visibleIdx]
+}
+
+// updateMouse handles clicks and wheel scrolling. Clicking a row moves the
+// cursor, clicking the gutter toggles, and clicking a linked item opens it.
+func (m model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	switch m.mode {
+	case ModeHelp:
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			m.helpScroll = min(m.helpScroll+1, m.maxHelpScroll())
+		case tea.MouseButtonWheelUp:
+			m.helpScroll = max(m.helpScroll-1, 0)
+		}
+		return m, nil
+	case ModeNormal:
+	default:
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelDown:
+		m.cursor = m.stepCursor(1)
+		return m, nil
+	case tea.MouseButtonWheelUp:
+		m.cursor = m.stepCursor(-1)
+		return m, nil
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	todoIdx := m.todoAtRow(msg.Y)
+	if todoIdx < 0 {
+		return m, nil
+	}
+
+	m.statusMessage = ""
+	m.noticeMessage = ""
+	m.pendingDelete = false
+	m.cursor = todoIdx
+
+	if msg.X < m.gutterWidth() {
+		return m.toggleCurrent()
+	}
+	if item := m.file.GetTodo(todoIdx); item.LinkedPath() != "" {
+		return m.navigateToLinkedFile(item)
+	}
+	return m, nil
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// longList returns a file of count todos named "item 1" to "item count".
+func longList(count int) string {
+	var b strings.Builder
+	b.WriteString("# Long\n\n")
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&b, "- [ ] item %d\n", i)
+	}
+	return b.String()
+}
+
+// rowOf returns the screen row showing text, or -1.
+func rowOf(view, text string) int {
+	for row, line := range strings.Split(view, "\n") {
+		if strings.HasSuffix(strings.TrimRight(line, " "), text) {
+			return row
+		}
+	}
+	return -1
+}
+
+func click(m model, x, y int) model {
+	return update(m, tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+}
+
+func TestView_ScrollsToCursor(t *testing.T) {
+	m := newTestModel(t, longList(40), DefaultConfig())
+	m.cursor = 39
+	view := m.View()
+	if got := len(strings.Split(view, "\n")); got > m.height {
+		t.Errorf("view is %d rows, taller than the %d-row terminal", got, m.height)
+	}
+	if rowOf(view, "item 40") < 0 {
+		t.Error("cursor row is not on screen")
+	}
+	if rowOf(view, "item 1") >= 0 {
+		t.Error("first row should be scrolled off")
+	}
+}
+
+func TestMouseClick_ScrolledList(t *testing.T) {
+	m := newTestModel(t, longList(40), DefaultConfig())
+	m.cursor = 39
+	row := rowOf(m.View(), "item 30")
+	if row < 0 {
+		t.Fatal("item 30 is not on screen")
+	}
+	m = click(m, 20, row)
+	if m.cursor != 29 {
+		t.Errorf("clicking item 30 moved the cursor to %d, want 29", m.cursor)
+	}
+}
+
+func TestMouseClick_ListTopAndGutter(t *testing.T) {
+	m := newTestModel(t, "# Short\n\n- [ ] one\n- [ ] two\n", DefaultConfig())
+	m.noticeMessage = "shifts the list down a row"
+	row := rowOf(m.View(), "two")
+	if row != m.listTop()+1 {
+		t.Fatalf("row of second todo = %d, want listTop()+1 = %d", row, m.listTop()+1)
+	}
+	m = click(m, 0, row)
+	if m.cursor != 1 || !m.file.GetTodo(1).Checked {
+		t.Errorf("gutter click: cursor %d, checked %v; want cursor 1 checked", m.cursor, m.file.GetTodo(1).Checked)
+	}
+	if got := m.todoAtRow(m.listTop() + 2); got != -1 {
+		t.Errorf("row below the list maps to todo %d, want -1", got)
+	}
+}
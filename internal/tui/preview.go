@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// minPreviewWidth is the narrowest terminal that still shows the preview pane.
+	minPreviewWidth = 70
+	// previewWidthPercent is the share of the terminal width given to the preview pane.
+	previewWidthPercent = 40
+)
+
+var previewPaneStyle = lipgloss.NewStyle().
+	Border(lipgloss.NormalBorder(), false, false, false, true).
+	PaddingLeft(1)
+
+// previewLoadedMsg is returned by loadPreviewCmd after parsing a linked file.
+type previewLoadedMsg struct {
+	path string
+	file *TodoFile
+	err  error
+}
+
+// loadPreviewCmd returns a tea.Cmd that parses a linked file for the preview pane.
+func loadPreviewCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		todoFile, err := ParseFile(path)
+		return previewLoadedMsg{path: path, file: todoFile, err: err}
+	}
+}
+
+// syncPreview starts loading the file linked from the cursor item when the
+// preview is open and the cursor has moved to a different link.
+func (m model) syncPreview() (model, tea.Cmd) {
+	if !m.showPreview {
+		return m, nil
+	}
+
+	var targetPath string
+	if m.file.TodoCount() > 0 && m.cursor < m.file.TodoCount() {
+		targetPath = m.resolver.Resolve(m.file.Path, m.file.GetTodo(m.cursor))
+	}
+	if targetPath == m.previewPath {
+		return m, nil
+	}
+
+	m.previewPath = targetPath
+	m.previewFile = nil
+	m.previewErr = nil
+	if targetPath == "" {
+		return m, nil
+	}
+	return m, loadPreviewCmd(targetPath)
+}
+
+// handlePreviewLoaded stores a parsed preview unless the cursor has since moved on.
+func (m model) handlePreviewLoaded(msg previewLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.path != m.previewPath {
+		return m, nil
+	}
+	m.previewFile = msg.file
+	m.previewErr = msg.err
+	return m, nil
+}
+
+// withPreviewPane places the preview pane to the right of the rendered list.
+// On narrow terminals the pane is replaced by a one-line hint.
+func (m model) withPreviewPane(list string) string {
+	if m.width < minPreviewWidth {
+		return list + helpStyle.Render("  (preview hidden: terminal too narrow)") + "\n"
+	}
+
+	paneWidth := m.width * previewWidthPercent / 100
+	listWidth := m.width - paneWidth
+	listBlock := lipgloss.NewStyle().Width(listWidth).MaxWidth(listWidth).
+		Render(strings.TrimSuffix(list, "\n"))
+	pane := previewPaneStyle.MaxWidth(paneWidth).Height(lipgloss.Height(listBlock)).
+		Render(m.renderPreview(paneWidth - 2))
+	return lipgloss.JoinHorizontal(lipgloss.Top, listBlock, pane) + "\n"
+}
+
+// renderPreview renders the read-only contents of the previewed file.
+func (m model) renderPreview(width int) string {
+	if m.previewPath == "" {
+		return helpStyle.Render("No linked file under cursor")
+	}
+	if m.previewErr != nil {
+		return errorStyle.Render(fmt.Sprintf("Error: %v", m.previewErr))
+	}
+	if m.previewFile == nil {
+		return helpStyle.Render("Loading…")
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.UnsetMarginBottom().Render(fileBasenameWithoutExtension(m.previewPath)))
+	if m.previewFile.TodoCount() == 0 {
+		lines = append(lines, helpStyle.Render("No todos."))
+	}
+
+	maxRows := m.previewFile.TodoCount()
+	if m.height > 0 {
+		maxRows = min(maxRows, max(1, m.height-m.listTop()-4))
+	}
+	for i := 0; i < maxRows; i++ {
+		item := m.previewFile.GetTodo(i)
+		box := "[ ] "
+		text := item.DisplayText()
+		switch {
+		case item.IsLinkedTodo() && item.Checked:
+			box = "[x] "
+			text = linkStyle.Strikethrough(true).Render(text)
+		case item.IsLinkedTodo():
+			text = linkStyle.Render(text)
+		case item.Checked:
+			box = "[x] "
+			text = checkedStyle.Render(text)
+		}
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(box+text))
+	}
+	if hidden := m.previewFile.TodoCount() - maxRows; hidden > 0 {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf("… %d more", hidden)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// writeLinkedFile writes content to name next to m's todo file and returns its path.
+func writeLinkedFile(t *testing.T, m model, name, content string) string {
+	t.Helper()
+	path := filepath.Join(filepath.Dir(m.file.Path), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHandlePreviewLoaded_DropsStaleLoad(t *testing.T) {
+	m := newTestModel(t, "- [ ] [A](a.md)\n- [ ] [B](b.md)\n", DefaultConfig())
+	pathA := writeLinkedFile(t, m, "a.md", "- [ ] from a\n")
+	pathB := writeLinkedFile(t, m, "b.md", "- [ ] from b\n")
+
+	next, loadA := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(model)
+	next, loadB := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = next.(model)
+	if m.previewPath != pathB {
+		t.Fatalf("previewPath = %q, want %q", m.previewPath, pathB)
+	}
+
+	m = update(m, loadA())
+	if m.previewFile != nil {
+		t.Fatalf("stale load of %s was kept", pathA)
+	}
+	m = update(m, loadB())
+	if m.previewFile == nil || m.previewFile.GetTodo(0).Text != "from b" {
+		t.Errorf("previewFile = %+v, want b.md", m.previewFile)
+	}
+}
+
+func TestWithPreviewPane_NarrowTerminalShowsHint(t *testing.T) {
+	m := newTestModel(t, "- [ ] [A](a.md)\n", DefaultConfig())
+	writeLinkedFile(t, m, "a.md", "- [ ] from a\n")
+	m = press(m, "tab")
+	m = update(m, tea.WindowSizeMsg{Width: minPreviewWidth - 1, Height: 24})
+
+	view := m.View()
+	if !strings.Contains(view, "preview hidden: terminal too narrow") {
+		t.Errorf("view has no narrow-terminal hint:\n%s", view)
+	}
+	if strings.Contains(view, "from a") {
+		t.Errorf("view still shows the preview pane:\n%s", view)
+	}
+}
+
+func TestRenderPreview_CapsRowsToHeight(t *testing.T) {
+	m := newTestModel(t, "- [ ] [A](a.md)\n", DefaultConfig())
+	var content strings.Builder
+	for i := range 30 {
+		fmt.Fprintf(&content, "- [ ] item %d\n", i)
+	}
+	writeLinkedFile(t, m, "a.md", content.String())
+	m = press(m, "tab")
+
+	shown := m.height - m.listTop() - 4
+	preview := m.renderPreview(40)
+	if want := fmt.Sprintf("… %d more", 30-shown); !strings.Contains(preview, want) {
+		t.Errorf("preview missing %q:\n%s", want, preview)
+	}
+	if !strings.Contains(preview, fmt.Sprintf("item %d", shown-1)) || strings.Contains(preview, fmt.Sprintf("item %d", shown)) {
+		t.Errorf("preview should show exactly %d rows:\n%s", shown, preview)
+	}
+}
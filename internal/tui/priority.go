@@ -0,0 +1,196 @@
+package tui
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Priority ranks a todo. Higher values sort first.
+type Priority int
+
+// Priorities, lowest first.
+const (
+	// PriorityNone is a todo without a priority marker.
+	PriorityNone Priority = iota
+	// PriorityLow is "(C)", "!", or 🔽.
+	PriorityLow
+	// PriorityMedium is "(B)", "!!", or 🔼.
+	PriorityMedium
+	// PriorityHigh is "(A)", "!!!", or ⏫.
+	PriorityHigh
+)
+
+var (
+	// letterPriorityRegex matches a todo.txt "(A) " priority at the start of
+	// the text.
+	letterPriorityRegex = regexp.MustCompile(`^\(([A-Z])\)(?:\s+|$)`)
+	// bangPriorityRegex matches a standalone !, !!, or !!! with the
+	// whitespace before it.
+	bangPriorityRegex = regexp.MustCompile(`(?:^|\s+)(!{1,3})(?:\s|$)`)
+	// emojiPriorityRegex matches an Obsidian Tasks priority emoji with the
+	// whitespace before it.
+	emojiPriorityRegex = regexp.MustCompile(`\s*(🔺|⏫|🔼|🔽|⏬)\x{FE0F}?`)
+)
+
+// emojiPriorities maps Obsidian Tasks priority emoji to priorities. Highest
+// and lowest fold into high and low.
+var emojiPriorities = map[string]Priority{
+	"🔺": PriorityHigh,
+	"⏫": PriorityHigh,
+	"🔼": PriorityMedium,
+	"🔽": PriorityLow,
+	"⏬": PriorityLow,
+}
+
+// priorityLetters are the todo.txt letters written for each priority.
+var priorityLetters = map[Priority]string{
+	PriorityHigh:   "A",
+	PriorityMedium: "B",
+	PriorityLow:    "C",
+}
+
+// Priority returns the todo's priority from a leading "(A)", "(B)", or "(C)"
+// (later letters count as low), a standalone "!!!", "!!", or "!", or an
+// Obsidian Tasks ⏫, 🔼, or 🔽.
+func (item TodoItem) Priority() Priority {
+	if match := letterPriorityRegex.FindStringSubmatch(item.Text); match != nil {
+		switch match[1] {
+		case "A":
+			return PriorityHigh
+		case "B":
+			return PriorityMedium
+		}
+		return PriorityLow
+	}
+	if match := bangPriorityRegex.FindStringSubmatch(item.Text); match != nil {
+		return Priority(len(match[1]))
+	}
+	if match := emojiPriorityRegex.FindStringSubmatch(item.Text); match != nil {
+		return emojiPriorities[match[1]]
+	}
+	return PriorityNone
+}
+
+// WithPriority returns the todo with its priority set to priority, written
+// in the syntax already in the text or as a leading "(A)", "(B)", or "(C)".
+// PriorityNone removes the marker.
+func (item TodoItem) WithPriority(priority Priority) TodoItem {
+	text := item.Text
+	switch {
+	case priority == PriorityNone:
+		text = stripPriority(text)
+	case letterPriorityRegex.MatchString(text):
+		text = "(" + priorityLetters[priority] + ") " + letterPriorityRegex.ReplaceAllString(text, "")
+	case bangPriorityRegex.MatchString(text):
+		loc := bangPriorityRegex.FindStringSubmatchIndex(text)
+		text = text[:loc[2]] + strings.Repeat("!", int(priority)) + text[loc[3]:]
+	case emojiPriorityRegex.MatchString(text):
+		loc := emojiPriorityRegex.FindStringSubmatchIndex(text)
+		text = text[:loc[2]] + priorityEmoji(priority) + text[loc[3]:]
+	default:
+		text = "(" + priorityLetters[priority] + ") " + text
+	}
+	item.Text = text
+	return item
+}
+
+// priorityEmoji returns the Obsidian Tasks emoji for priority.
+func priorityEmoji(priority Priority) string {
+	switch priority {
+	case PriorityHigh:
+		return "⏫"
+	case PriorityMedium:
+		return "🔼"
+	case PriorityLow:
+		return "🔽"
+	}
+	return ""
+}
+
+// stripPriority removes the priority marker from text.
+func stripPriority(text string) string {
+	switch {
+	case letterPriorityRegex.MatchString(text):
+		return letterPriorityRegex.ReplaceAllString(text, "")
+	case bangPriorityRegex.MatchString(text):
+		loc := bangPriorityRegex.FindStringSubmatchIndex(text)
+		return strings.TrimSpace(text[:loc[0]] + text[loc[3]:])
+	case emojiPriorityRegex.MatchString(text):
+		return strings.TrimSpace(emojiPriorityRegex.ReplaceAllString(text, ""))
+	}
+	return text
+}
+
+// SortBySection reorders the top-level todos under each heading by priority,
+// highest first, then by due date, earliest first, with undated todos after
+// dated ones. Each todo takes the indented lines below it, such as subtasks,
+// along unsorted. Todos otherwise keep their order, and headings and other
+// lines keep their place among the todos.
+func (tf *TodoFile) SortBySection() {
+	lines := make([]string, 0, len(tf.RawLines))
+	for start := 0; start < len(tf.RawLines); {
+		end := start + 1
+		for end < len(tf.RawLines) && !headingRegex.MatchString(tf.RawLines[end]) {
+			end++
+		}
+		lines = append(lines, sortSection(tf.RawLines[start:end])...)
+		start = end
+	}
+	tf.RawLines = lines
+	tf.rebuildIndices()
+}
+
+// sortSection sorts the top-level todos in the lines under one heading, each
+// with the indented lines directly below it, and returns the new lines.
+func sortSection(lines []string) []string {
+	var segments [][]string
+	var blocks []int // indexes of the segments holding a todo and its children
+	for i := 0; i < len(lines); {
+		end := i + 1
+		if todoRegex.MatchString(lines[i]) && !isIndented(lines[i]) {
+			for end < len(lines) && isIndented(lines[end]) {
+				end++
+			}
+			blocks = append(blocks, len(segments))
+		}
+		segments = append(segments, lines[i:end])
+		i = end
+	}
+
+	sorted := make([][]string, len(blocks))
+	for i, segment := range blocks {
+		sorted[i] = segments[segment]
+	}
+	slices.SortStableFunc(sorted, func(a, b []string) int {
+		return compareTodoLines(a[0], b[0])
+	})
+	for i, segment := range blocks {
+		segments[segment] = sorted[i]
+	}
+	return slices.Concat(segments...)
+}
+
+// isIndented reports whether line is non-blank and starts with whitespace.
+func isIndented(line string) bool {
+	return strings.TrimSpace(line) != "" && strings.TrimLeft(line, " \t") != line
+}
+
+// compareTodoLines orders two raw todo lines for SortBySection.
+func compareTodoLines(a, b string) int {
+	itemA, itemB := ParseTodoLine(a), ParseTodoLine(b)
+	if priorityA, priorityB := itemA.Priority(), itemB.Priority(); priorityA != priorityB {
+		return int(priorityB) - int(priorityA)
+	}
+	dueA, okA := itemA.DueDate()
+	dueB, okB := itemB.DueDate()
+	switch {
+	case okA && okB:
+		return dueA.Compare(dueB)
+	case okA:
+		return -1
+	case okB:
+		return 1
+	}
+	return 0
+}
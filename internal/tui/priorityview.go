@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// priorityBadge renders the todo's priority as a colored !!!, !!, or ! in
+// front of its text, or "" when it has none.
+func priorityBadge(item TodoItem) string {
+	switch item.Priority() {
+	case PriorityHigh:
+		return priorityHighStyle.Render("!!!") + " "
+	case PriorityMedium:
+		return priorityMediumStyle.Render("!!") + " "
+	case PriorityLow:
+		return priorityLowStyle.Render("!") + " "
+	}
+	return ""
+}
+
+// shiftPriority raises (delta 1) or lowers (delta -1) the current todo's
+// priority one step and saves.
+func (m model) shiftPriority(delta int) (tea.Model, tea.Cmd) {
+	if m.file.TodoCount() == 0 {
+		return m, nil
+	}
+	item := m.file.GetTodo(m.cursor)
+	priority := min(max(item.Priority()+Priority(delta), PriorityNone), PriorityHigh)
+	if priority == item.Priority() {
+		return m, nil
+	}
+	m.file.SetTodoText(m.cursor, item.WithPriority(priority).Text)
+	if err := m.file.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+	}
+	return m, nil
+}
+
+// sortBySection sorts the todos in each section by priority and due date,
+// keeping the cursor on the same todo, and saves.
+func (m model) sortBySection() (tea.Model, tea.Cmd) {
+	if m.file.TodoCount() == 0 {
+		return m, nil
+	}
+	cursorLine := m.file.RawLines[m.file.TodoIndices[m.cursor]]
+	m.file.SortBySection()
+	for i, lineIdx := range m.file.TodoIndices {
+		if m.file.RawLines[lineIdx] == cursorLine {
+			m.cursor = i
+			break
+		}
+	}
+	if err := m.file.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Query status filters.
+const (
+	QueryStatusAll  = "all"
+	QueryStatusOpen = "open"
+	QueryStatusDone = "done"
+)
+
+// Query is a parsed filter over todos. Every term must match.
+//
+// Terms are separated by spaces; double quotes group words. Supported terms:
+//
+//	status:open|done|all   checkbox state
+//	tag:name               has #name, or @name or +name when written so
+//	due-before:DATE        due date before DATE (see ParseDate)
+//	file:GLOB              file path relative to the root, or its base name
+//	section:text           nearest heading contains text
+//	anything else          text contains it
+//
+// Matching is case-insensitive.
+type Query struct {
+	// Source is the query as typed.
+	Source string
+
+	status    string
+	words     []string
+	tags      []string
+	dueBefore time.Time
+	fileGlobs []string
+	sections  []string
+}
+
+// QueryResult is a todo matched by a query, located in its owning file.
+type QueryResult struct {
+	// Path is the absolute path of the file containing the todo.
+	Path string
+	// TodoIndex is the todo's logical index in Path.
+	TodoIndex int
+	// Line is the todo's 1-based line number in Path.
+	Line int
+	// Section is the nearest heading above the todo.
+	Section string
+	Item    TodoItem
+}
+
+// ParseQuery parses source into a Query. An empty source matches everything.
+func ParseQuery(source string) (Query, error) {
+	query := Query{Source: source, status: QueryStatusAll}
+	for _, token := range splitQuery(source) {
+		key, value, hasKey := strings.Cut(token, ":")
+		if !hasKey || value == "" {
+			query.words = append(query.words, strings.ToLower(token))
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "status":
+			value = strings.ToLower(value)
+			if value != QueryStatusAll && value != QueryStatusOpen && value != QueryStatusDone {
+				return Query{}, fmt.Errorf("invalid status %q (want open, done, or all)", value)
+			}
+			query.status = value
+		case "tag":
+			query.tags = append(query.tags, value)
+		case "due-before":
+			due, err := ParseDate(value, time.Now())
+			if err != nil {
+				return Query{}, err
+			}
+			query.dueBefore = due
+		case "file":
+			query.fileGlobs = append(query.fileGlobs, value)
+		case "section":
+			query.sections = append(query.sections, strings.ToLower(value))
+		default:
+			query.words = append(query.words, strings.ToLower(token))
+		}
+	}
+	return query, nil
+}
+
+// splitQuery splits source on spaces, keeping double-quoted runs together
+// and dropping the quotes.
+func splitQuery(source string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range source {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+// Matches reports whether result satisfies every term. File globs match
+// the path relative to root.
+func (q Query) Matches(result QueryResult, root string) bool {
+	item := result.Item
+	switch q.status {
+	case QueryStatusOpen:
+		if item.Checked {
+			return false
+		}
+	case QueryStatusDone:
+		if !item.Checked {
+			return false
+		}
+	}
+
+	text := strings.ToLower(item.Text)
+	for _, word := range q.words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	for _, tag := range q.tags {
+		if !item.HasTag(tag) {
+			return false
+		}
+	}
+	if !q.dueBefore.IsZero() {
+		due, ok := item.DueDate()
+		if !ok || !due.Before(q.dueBefore) {
+			return false
+		}
+	}
+	section := strings.ToLower(result.Section)
+	for _, want := range q.sections {
+		if !strings.Contains(section, want) {
+			return false
+		}
+	}
+	if len(q.fileGlobs) > 0 && !matchesAnyGlob(q.fileGlobs, RelativeDisplayPath(root, result.Path)) {
+		return false
+	}
+	return true
+}
+
+// matchesAnyGlob reports whether relativePath, or its base name for
+// patterns without a slash, matches one of globs.
+func matchesAnyGlob(globs []string, relativePath string) bool {
+	for _, glob := range globs {
+		target := relativePath
+		if !strings.Contains(glob, "/") {
+			target = path.Base(relativePath)
+		}
+		if matched, _ := path.Match(strings.ToLower(glob), strings.ToLower(target)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RunQuery returns the todos in every readable file of graph that match
+// query, in graph order and then file order.
+func RunQuery(graph *LinkGraph, root string, query Query) []QueryResult {
+	var results []QueryResult
+	for _, node := range graph.Nodes {
+		if node.File == nil {
+			continue
+		}
+		for i := 0; i < node.File.TodoCount(); i++ {
+			result := QueryResult{
+				Path:      node.Path,
+				TodoIndex: i,
+				Line:      node.File.TodoIndices[i] + 1,
+				Section:   node.File.Section(i),
+				Item:      node.File.GetTodo(i),
+			}
+			if query.Matches(result, root) {
+				results = append(results, result)
+			}
+		}
+	}
+	return results
+}
+
+// Agenda returns the results that have a due date, earliest first. Results
+// due on the same day keep their order.
+func Agenda(results []QueryResult) []QueryResult {
+	var dated []QueryResult
+	for _, result := range results {
+		if _, ok := result.Item.DueDate(); ok {
+			dated = append(dated, result)
+		}
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		dueI, _ := dated[i].Item.DueDate()
+		dueJ, _ := dated[j].Item.DueDate()
+		return dueI.Before(dueJ)
+	})
+	return dated
+}
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// queryResultsMsg is returned by runQueryCmd with the todos matching a query.
+type queryResultsMsg struct {
+	query   Query
+	results []QueryResult
+	err     error
+}
+
+// runQueryCmd returns a tea.Cmd that evaluates query across every file
+// reachable from rootPath.
+func runQueryCmd(rootPath string, query Query, resolver *LinkResolver) tea.Cmd {
+	return func() tea.Msg {
+		graph := BuildLinkGraph(rootPath, resolver)
+		if err := graph.Root().Err; err != nil {
+			return queryResultsMsg{query: query, err: err}
+		}
+		return queryResultsMsg{query: query, results: RunQuery(graph, resolver.VaultRoot, query)}
+	}
+}
+
+// handleQueryResults shows the results of a finished query.
+func (m model) handleQueryResults(msg queryResultsMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+		return m, nil
+	}
+	m.query = msg.query
+	m.queryResults = msg.results
+	m.queryCursor = min(m.queryCursor, max(0, len(m.queryResults)-1))
+	m.mode = ModeQuery
+	return m, nil
+}
+
+// updateQueryInput handles typing a query at the prompt.
+func (m model) updateQueryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		query, err := ParseQuery(strings.TrimSpace(m.textInput.Value()))
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		m.queryCursor = 0
+		return m, runQueryCmd(m.treeRootPath(), query, m.resolver)
+	case key.Matches(msg, m.keys.Cancel):
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// updateQuery handles the query results list.
+func (m model) updateQuery(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.queryCursor < len(m.queryResults)-1 {
+			m.queryCursor++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.queryCursor > 0 {
+			m.queryCursor--
+		}
+	case key.Matches(msg, m.keys.Confirm):
+		if len(m.queryResults) > 0 {
+			return m.openQueryResult(m.queryResults[m.queryCursor])
+		}
+	case key.Matches(msg, m.keys.Toggle), key.Matches(msg, m.keys.ToggleOnly):
+		if len(m.queryResults) > 0 {
+			m = m.editQueryResult(func(todoFile *TodoFile, todoIdx int) string {
+				if nextIdx := todoFile.ToggleTodo(todoIdx); nextIdx >= 0 {
+					return m.recurrenceNotice(todoFile.GetTodo(nextIdx))
+				}
+				return ""
+			})
+		}
+	case key.Matches(msg, m.keys.Edit):
+		if len(m.queryResults) > 0 {
+			m.mode = ModeQueryEdit
+			return m.startTextInput(m.queryResults[m.queryCursor].Item.Text)
+		}
+	case key.Matches(msg, m.keys.Query):
+		m.mode = ModeQueryInput
+		return m.startTextInput(m.query.Source)
+	case key.Matches(msg, m.keys.Quit):
+		m.mode = ModeNormal
+		return m, loadProgressCmd(m.file, m.resolver)
+	}
+	return m, nil
+}
+
+// updateQueryEdit handles inline editing of a query result's text.
+func (m model) updateQueryEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		text := m.textInput.Value()
+		m = m.editQueryResult(func(todoFile *TodoFile, todoIdx int) string {
+			todoFile.SetTodoText(todoIdx, text)
+			return ""
+		})
+		m.textInput.Blur()
+		m.mode = ModeQuery
+		return m, nil
+	case key.Matches(msg, m.keys.Cancel):
+		m.textInput.Blur()
+		m.mode = ModeQuery
+		return m, nil
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+	}
+}
+
+// editQueryResult applies change to the selected result's todo in the file
+// that owns it and saves that file, showing any notice change returns. The
+// current file is edited in memory so the list stays in sync. If the todo no
+// longer matches what the query found, nothing is written.
+func (m model) editQueryResult(change func(todoFile *TodoFile, todoIdx int) string) model {
+	result := m.queryResults[m.queryCursor]
+	todoFile := m.file
+	if absPath(m.file.Path) != result.Path {
+		parsed, err := ParseFile(result.Path)
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", err)
+			return m
+		}
+		todoFile = parsed
+		todoFile.Options = m.config.FileOptions()
+	}
+	if result.TodoIndex >= todoFile.TodoCount() || todoFile.GetTodo(result.TodoIndex) != result.Item {
+		m.statusMessage = "Error: file changed since the query ran; run it again"
+		return m
+	}
+
+	todoCount := todoFile.TodoCount()
+	notice := change(todoFile, result.TodoIndex)
+	if err := todoFile.Save(); err != nil {
+		m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+		return m
+	}
+	m.noticeMessage = notice
+	// A completed repeating todo adds a line; shift later results in the
+	// same file so they still point at their todos.
+	added := todoFile.TodoCount() - todoCount
+	results := append([]QueryResult(nil), m.queryResults...)
+	for i := range results {
+		if results[i].Path == result.Path && results[i].TodoIndex > result.TodoIndex {
+			results[i].TodoIndex += added
+			results[i].Line += added
+		}
+	}
+	// A copy placed in_place takes the edited todo's line and pushes the
+	// edited todo below it.
+	todoIdx := result.TodoIndex
+	if todoFile.Options.RecurInPlace {
+		todoIdx += added
+	}
+	results[m.queryCursor].TodoIndex = todoIdx
+	results[m.queryCursor].Line = todoFile.TodoIndices[todoIdx] + 1
+	results[m.queryCursor].Item = todoFile.GetTodo(todoIdx)
+	m.queryResults = results
+	return m
+}
+
+// openQueryResult opens the file owning result with the cursor on its todo.
+// The current file is pushed so going back returns here.
+func (m model) openQueryResult(result QueryResult) (tea.Model, tea.Cmd) {
+	m.mode = ModeNormal
+	if absPath(m.file.Path) == result.Path {
+		m.cursor = min(result.TodoIndex, max(0, m.file.TodoCount()-1))
+		return m, loadProgressCmd(m.file, m.resolver)
+	}
+	if len(m.navStack) >= maxNavStackDepth {
+		m.statusMessage = fmt.Sprintf("Error: maximum navigation depth (%d) reached", maxNavStackDepth)
+		return m, nil
+	}
+	stack := append(append([]navigationEntry(nil), m.navStack...), navigationEntry{
+		FilePath:       m.file.Path,
+		CursorPosition: m.cursor,
+	})
+	return m, jumpToFileCmd(result.Path, result.TodoIndex, stack)
+}
+
+// renderQuery renders the query and its matches, each followed by its file.
+func (m model) renderQuery() string {
+	var b strings.Builder
+	source := m.query.Source
+	if source == "" {
+		source = "(everything)"
+	}
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  Query: %s  (%d matches)", source, len(m.queryResults))))
+	b.WriteString("\n\n")
+
+	if len(m.queryResults) == 0 {
+		b.WriteString(helpStyle.Render("  No todos match."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	visibleRows := len(m.queryResults)
+	if m.height > 0 {
+		visibleRows = min(visibleRows, max(1, m.height-m.listTop()-5))
+	}
+	start := max(0, m.queryCursor-visibleRows+1)
+	end := min(len(m.queryResults), start+visibleRows)
+
+	for i := start; i < end; i++ {
+		result := m.queryResults[i]
+		box := "[ ] "
+		if result.Item.Checked {
+			box = "[x] "
+		}
+		source := m.dueBadge(result.Item) + repeatBadge(result.Item) + m.doneBadge(result.Item) + helpStyle.Render("  "+RelativeDisplayPath(m.resolver.VaultRoot, result.Path))
+
+		if i == m.queryCursor && m.mode == ModeQueryEdit {
+			b.WriteString(cursorStyle.Render(" > "+box) + m.textInput.View())
+		} else if i == m.queryCursor {
+			textStyle := cursorStyle
+			if result.Item.Checked {
+				textStyle = textStyle.Strikethrough(true)
+			}
+			b.WriteString(cursorStyle.Render(" > "+box) + priorityBadge(result.Item) + textStyle.Render(result.Item.DisplayText()) + source)
+		} else if result.Item.Checked {
+			b.WriteString("   " + box + priorityBadge(result.Item) + checkedStyle.Render(result.Item.DisplayText()) + source)
+		} else {
+			b.WriteString("   " + box + priorityBadge(result.Item) + result.Item.DisplayText() + source)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
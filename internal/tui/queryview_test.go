@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueryToggle_RecurInPlaceShowsCheckedRow(t *testing.T) {
+	for _, placement := range []string{RecurrenceAfter, RecurrenceInPlace} {
+		config := DefaultConfig()
+		config.Recurrence = placement
+		m := newTestModel(t, "- [ ] Trash every:week due:2026-10-20\n- [ ] Dishes\n", config)
+
+		m = press(m, "/", "enter")
+		if m.mode != ModeQuery || len(m.queryResults) != 2 {
+			t.Fatalf("%s: expected 2 query results, got %d in mode %v", placement, len(m.queryResults), m.mode)
+		}
+		m = press(m, " ")
+
+		row := m.queryResults[0]
+		if !row.Item.Checked || row.Item.Text != "Trash every:week due:2026-10-20 ✅ "+time.Now().Format(dueDateLayout) {
+			t.Errorf("%s: toggled row shows %+v, want the checked original", placement, row.Item)
+		}
+		if got := m.file.GetTodo(row.TodoIndex); got != row.Item {
+			t.Errorf("%s: row points at todo %d, %+v", placement, row.TodoIndex, got)
+		}
+		if got := m.file.GetTodo(m.queryResults[1].TodoIndex).Text; got != "Dishes" {
+			t.Errorf("%s: second row points at %q, want Dishes", placement, got)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// everyRegex matches an every:RULE token, e.g. every:week or every:2w.
+	everyRegex = regexp.MustCompile(`(?i)(?:^|\s)every:([a-z0-9]+)\b`)
+	// repeatRegex matches an Obsidian Tasks "🔁 every RULE" annotation, e.g.
+	// "🔁 every monday" or "🔁 every 2 weeks".
+	repeatRegex = regexp.MustCompile(`(?i)🔁\s*every\s+((?:\d+\s+)?[a-z]+)`)
+	// recurrenceTokenRegex matches either repeat form with the whitespace
+	// before it, so it can be removed from the text.
+	recurrenceTokenRegex = regexp.MustCompile(`(?i)(?:^|\s+)(?:every:[a-z0-9]+\b|🔁\s*every\s+(?:\d+\s+)?[a-z]+)`)
+	// intervalRegex splits a rule such as "2w", "2 weeks", or "week" into a
+	// count and a unit.
+	intervalRegex = regexp.MustCompile(`^(\d*)\s*([a-z]+)$`)
+)
+
+// Recurrence returns the todo's repeat rule in lower case, e.g. "week",
+// "monday", or "2 weeks", or "" if it does not repeat or the rule is not
+// understood.
+func (item TodoItem) Recurrence() string {
+	var rule string
+	if match := everyRegex.FindStringSubmatch(item.Text); match != nil {
+		rule = match[1]
+	} else if match := repeatRegex.FindStringSubmatch(item.Text); match != nil {
+		rule = match[1]
+	}
+	rule = strings.ToLower(strings.Join(strings.Fields(rule), " "))
+	if _, ok := nextOccurrence(rule, time.Time{}); !ok {
+		return ""
+	}
+	return rule
+}
+
+// NextOccurrence returns an unchecked copy of a repeating todo due on the
+// next date its rule gives after its current due date, or after today when it
+// has none. The bool is false when the todo does not repeat.
+func (item TodoItem) NextOccurrence(now time.Time) (TodoItem, bool) {
+	rule := item.Recurrence()
+	if rule == "" {
+		return TodoItem{}, false
+	}
+	base, ok := item.DueDate()
+	if !ok {
+		base = StartOfDay(now)
+	}
+	next, _ := nextOccurrence(rule, base)
+	item.Checked = false
+	return item.WithDueDate(next), true
+}
+
+// nextOccurrence applies rule to base. Rules are a weekday name (the next
+// such day after base), or an optional count and a unit: d/day/daily,
+// w/week/weekly, m/month/monthly, or y/year/yearly, singular or plural.
+func nextOccurrence(rule string, base time.Time) (time.Time, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if rule == name || rule == name[:3] {
+			return base.AddDate(0, 0, (int(day)-int(base.Weekday())+6)%7+1), true
+		}
+	}
+
+	match := intervalRegex.FindStringSubmatch(rule)
+	if match == nil {
+		return time.Time{}, false
+	}
+	count := 1
+	if match[1] != "" {
+		count, _ = strconv.Atoi(match[1])
+		if count < 1 {
+			return time.Time{}, false
+		}
+	}
+	switch strings.TrimSuffix(match[2], "s") {
+	case "d", "day", "daily":
+		return base.AddDate(0, 0, count), true
+	case "w", "week", "weekly":
+		return base.AddDate(0, 0, 7*count), true
+	case "m", "month", "monthly":
+		return addMonths(base, count), true
+	case "y", "year", "yearly":
+		return addMonths(base, 12*count), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Progress counts completed and total todos beneath a file, following links.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// Complete reports whether there is at least one todo and all are done.
+func (p Progress) Complete() bool {
+	return p.Total > 0 && p.Done == p.Total
+}
+
+// ProgressCache memoizes rollup progress by absolute file path.
+type ProgressCache map[string]Progress
+
+// FileProgress returns the rollup progress of the file at path. Each plain
+// todo counts once; each linked todo is replaced by the progress of the file
+// it links to, as resolved by resolver. Links that are broken, empty, or lead
+// back to an ancestor count as a single todo using their own checkbox.
+func FileProgress(path string, resolver *LinkResolver, cache ProgressCache) (Progress, error) {
+	return fileProgress(path, resolver, cache, map[string]bool{})
+}
+
+// fileProgress computes FileProgress; ancestors holds the absolute paths
+// currently being computed so cycles terminate.
+func fileProgress(path string, resolver *LinkResolver, cache ProgressCache, ancestors map[string]bool) (Progress, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		absolutePath = filepath.Clean(path)
+	}
+	if progress, ok := cache[absolutePath]; ok {
+		return progress, nil
+	}
+
+	todoFile, err := ParseFile(path)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	ancestors[absolutePath] = true
+	defer delete(ancestors, absolutePath)
+
+	var progress Progress
+	for i := 0; i < todoFile.TodoCount(); i++ {
+		item := todoFile.GetTodo(i)
+		if childProgress, ok := linkedProgress(path, item, resolver, cache, ancestors); ok {
+			progress.Done += childProgress.Done
+			progress.Total += childProgress.Total
+			continue
+		}
+		progress.Total++
+		if item.Checked {
+			progress.Done++
+		}
+	}
+
+	cache[absolutePath] = progress
+	return progress, nil
+}
+
+// linkedProgress returns the progress of the file item links to, or false if
+// the item is not a usable link.
+func linkedProgress(currentFilePath string, item TodoItem, resolver *LinkResolver, cache ProgressCache, ancestors map[string]bool) (Progress, bool) {
+	resolvedPath := resolver.Resolve(currentFilePath, item)
+	if resolvedPath == "" {
+		return Progress{}, false
+	}
+	absolutePath, err := filepath.Abs(resolvedPath)
+	if err != nil || ancestors[absolutePath] {
+		return Progress{}, false
+	}
+	progress, err := fileProgress(resolvedPath, resolver, cache, ancestors)
+	if err != nil || progress.Total == 0 {
+		return Progress{}, false
+	}
+	return progress, true
+}
+
+// LinkProgress returns the rollup progress of the file linked from item,
+// resolved from currentFilePath with resolver. The bool is false when item is
+// not a link or its target cannot be read.
+func LinkProgress(currentFilePath string, item TodoItem, resolver *LinkResolver, cache ProgressCache) (Progress, bool) {
+	ancestors := map[string]bool{}
+	if absolutePath, err := filepath.Abs(currentFilePath); err == nil {
+		ancestors[absolutePath] = true
+	}
+	return linkedProgress(currentFilePath, item, resolver, cache, ancestors)
+}
+
+// progressLoadedMsg carries rollup progress for the links in one file,
+// keyed by resolved link path.
+type progressLoadedMsg struct {
+	filePath string
+	progress map[string]Progress
+}
+
+// loadProgressCmd returns a tea.Cmd that computes rollup progress for every
+// linked todo in todoFile.
+func loadProgressCmd(todoFile *TodoFile, resolver *LinkResolver) tea.Cmd {
+	filePath := todoFile.Path
+	var items []TodoItem
+	for i := 0; i < todoFile.TodoCount(); i++ {
+		if item := todoFile.GetTodo(i); item.LinkedPath() != "" {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		cache := ProgressCache{}
+		progressByPath := map[string]Progress{}
+		for _, item := range items {
+			if progress, ok := LinkProgress(filePath, item, resolver, cache); ok {
+				progressByPath[resolver.Resolve(filePath, item)] = progress
+			}
+		}
+		return progressLoadedMsg{filePath: filePath, progress: progressByPath}
+	}
+}
+
+// handleProgressLoaded stores rollup progress for the current file and, when
+// auto_check_links is enabled, checks linked items whose files are complete.
+// A repeating item is only checked when its file has newly become complete,
+// so the copy its recurrence inserts waits for the next completion.
+func (m model) handleProgressLoaded(msg progressLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.filePath != m.file.Path {
+		return m, nil
+	}
+	m.linkProgress = msg.progress
+	previous := maps.Clone(m.seenProgress)
+	if m.seenProgress == nil {
+		m.seenProgress = map[string]Progress{}
+	}
+	maps.Copy(m.seenProgress, msg.progress)
+
+	if !m.config.AutoCheckLinks {
+		return m, nil
+	}
+	// Collect the items first: checking a repeating one inserts an unchecked
+	// copy that must not be checked in turn.
+	var complete []int
+	for i := 0; i < m.file.TodoCount(); i++ {
+		item := m.file.GetTodo(i)
+		if item.Checked {
+			continue
+		}
+		progress, ok := m.itemProgress(item)
+		if !ok || !progress.Complete() {
+			continue
+		}
+		if item.Recurrence() != "" && !m.newlyComplete(item, previous) {
+			continue
+		}
+		complete = append(complete, i)
+	}
+	// Bottom up, so inserted copies don't shift the items still to check.
+	for _, i := range slices.Backward(complete) {
+		if nextIdx := m.file.ToggleTodo(i); nextIdx >= 0 && nextIdx <= m.cursor {
+			m.cursor++
+		}
+	}
+	if len(complete) > 0 {
+		if err := m.file.Save(); err != nil {
+			m.statusMessage = fmt.Sprintf("Error saving: %v", err)
+		}
+	}
+	return m, nil
+}
+
+// newlyComplete reports whether the file a repeating item links to was not
+// complete when last seen. A file not seen yet this session counts as newly
+// complete unless the current file already records a checked occurrence.
+func (m model) newlyComplete(item TodoItem, previous map[string]Progress) bool {
+	resolvedPath := m.resolver.Resolve(m.file.Path, item)
+	if progress, ok := previous[resolvedPath]; ok {
+		return !progress.Complete()
+	}
+	for i := 0; i < m.file.TodoCount(); i++ {
+		other := m.file.GetTodo(i)
+		if other.Checked && other.Recurrence() != "" && m.resolver.Resolve(m.file.Path, other) == resolvedPath {
+			return false
+		}
+	}
+	return true
+}
+
+// itemProgress looks up the loaded rollup progress for a linked item.
+func (m model) itemProgress(item TodoItem) (Progress, bool) {
+	resolvedPath := m.resolver.Resolve(m.file.Path, item)
+	if resolvedPath == "" {
+		return Progress{}, false
+	}
+	progress, ok := m.linkProgress[resolvedPath]
+	return progress, ok
+}
+
+// displayText returns the text shown for an item in the list. Linked items
+// with readable targets show their link label plus rollup progress, e.g.
+// "work/tasks (7/12)", followed by any labels on a todo: line; everything
+// else shows its text with wiki and markdown links reduced to their labels.
+func (m model) displayText(item TodoItem) string {
+	progress, ok := m.itemProgress(item)
+	if !ok {
+		return item.DisplayText()
+	}
+	link := item.Link()
+	if link.Kind == LinkTodo {
+		// The label hides the rest of a todo: line, so keep the labels after
+		// the path, but not a "#" or "+" inside the file name.
+		_, afterPath, _ := strings.Cut(item.Text, link.Target)
+		labels := TodoItem{Text: afterPath}.Labels()
+		return strings.Join(append([]string{fmt.Sprintf("%s (%d/%d)", link.Label, progress.Done, progress.Total)}, labels...), " ")
+	}
+	return fmt.Sprintf("%s (%d/%d)", item.DisplayText(), progress.Done, progress.Total)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAutoCheckLinks_RepeatingLinkedTodo(t *testing.T) {
+	for _, placement := range []string{RecurrenceAfter, RecurrenceInPlace} {
+		config := DefaultConfig()
+		config.AutoCheckLinks = true
+		config.Recurrence = placement
+		m := newTestModel(t, "- [ ] [[work]] every:week due:2026-10-20\n- [ ] Other\n", config)
+		work := filepath.Join(filepath.Dir(m.file.Path), "work.md")
+		if err := os.WriteFile(work, []byte("- [x] Done\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		done := make(chan model)
+		go func() { done <- update(m, m.Init()()) }()
+		select {
+		case m = <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: auto-checking a repeating linked todo did not finish", placement)
+		}
+
+		stamp := " ✅ " + time.Now().Format(dueDateLayout)
+		want := map[string]string{
+			RecurrenceAfter:   "- [x] [[work]] every:week due:2026-10-20" + stamp + "\n- [ ] [[work]] every:week due:2026-10-27\n- [ ] Other\n",
+			RecurrenceInPlace: "- [ ] [[work]] every:week due:2026-10-27\n- [x] [[work]] every:week due:2026-10-20" + stamp + "\n- [ ] Other\n",
+		}[placement]
+		data, _ := os.ReadFile(m.file.Path)
+		if string(data) != want {
+			t.Errorf("%s: file =\n%s\nwant\n%s", placement, data, want)
+		}
+		if !m.file.GetTodo(m.cursor).Checked {
+			t.Errorf("%s: cursor moved off the checked todo to %q", placement, m.file.GetTodo(m.cursor).Text)
+		}
+	}
+}
+
+func TestAutoCheckLinks_RepeatingLinkedTodoOncePerCompletion(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoCheckLinks = true
+	m := newTestModel(t, "- [ ] [[work]] every:week due:2026-10-20\n", config)
+	work := filepath.Join(filepath.Dir(m.file.Path), "work.md")
+	writeWork := func(content string) {
+		if err := os.WriteFile(work, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reload := func(m model) model { return update(m, loadProgressCmd(m.file, m.resolver)()) }
+	assertFile := func(step, want string) {
+		t.Helper()
+		data, _ := os.ReadFile(m.file.Path)
+		if string(data) != want {
+			t.Errorf("%s: file =\n%s\nwant\n%s", step, data, want)
+		}
+	}
+
+	writeWork("- [x] Done\n")
+	m = reload(reload(reload(m)))
+	done := " ✅ " + time.Now().Format(dueDateLayout)
+	once := "- [x] [[work]] every:week due:2026-10-20" + done + "\n- [ ] [[work]] every:week due:2026-10-27\n"
+	assertFile("reloaded while complete", once)
+
+	// A new session has seen no progress yet.
+	m.seenProgress = nil
+	m = reload(m)
+	assertFile("restarted while complete", once)
+
+	writeWork("- [ ] Done\n")
+	m = reload(m)
+	writeWork("- [x] Done\n")
+	m = reload(m)
+	assertFile("completed again", "- [x] [[work]] every:week due:2026-10-20"+done+"\n- [x] [[work]] every:week due:2026-10-27"+done+"\n- [ ] [[work]] every:week due:2026-11-03\n")
+}
+
+func TestDisplayText_LinkedTodoKeepsLabels(t *testing.T) {
+	m := newTestModel(t, "- [ ] todo:work.md #work @office\n", DefaultConfig())
+	work := filepath.Join(filepath.Dir(m.file.Path), "work.md")
+	if err := os.WriteFile(work, []byte("- [x] Done\n- [ ] Open\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m = update(m, m.Init()())
+	if got := m.displayText(m.file.GetTodo(0)); got != "work (1/2) #work @office" {
+		t.Errorf("displayText = %q, want %q", got, "work (1/2) #work @office")
+	}
+}
+
+func TestDisplayText_LinkedTodoPathWithLabelCharacters(t *testing.T) {
+	m := newTestModel(t, "- [ ] todo:Project #1.md #work\n", DefaultConfig())
+	work := filepath.Join(filepath.Dir(m.file.Path), "Project #1.md")
+	if err := os.WriteFile(work, []byte("- [x] Done\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m = update(m, m.Init()())
+	if got := m.displayText(m.file.GetTodo(0)); got != "Project #1 (1/1) #work" {
+		t.Errorf("displayText = %q, want %q", got, "Project #1 (1/1) #work")
+	}
+}
@@ -2,37 +2,84 @@ package tui
 
 import "github.com/charmbracelet/lipgloss"
 
+// Styles carry only text attributes here; foreground colors come from the
+// active theme via applyTheme.
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("170")).
 			MarginBottom(1)
 
-	cursorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("212"))
+	cursorStyle = lipgloss.NewStyle()
 
 	checkedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
 			Strikethrough(true)
 
 	rearrangeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
 			Bold(true)
 
-	priorityStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("243"))
+	priorityStyle = lipgloss.NewStyle()
 
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+	helpStyle = lipgloss.NewStyle()
 
 	deleteStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
 			Bold(true)
 
 	linkStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("33")).
 			Underline(true)
 
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
+	errorStyle = lipgloss.NewStyle()
+
+	noticeStyle = lipgloss.NewStyle()
+
+	selectStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	overdueStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	dueTodayStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	upcomingStyle = lipgloss.NewStyle()
+
+	priorityHighStyle = lipgloss.NewStyle().
+				Bold(true)
+
+	priorityMediumStyle = lipgloss.NewStyle().
+				Bold(true)
+
+	priorityLowStyle = lipgloss.NewStyle()
+
+	tagStyle = lipgloss.NewStyle()
+
+	contextStyle = lipgloss.NewStyle()
+
+	projectStyle = lipgloss.NewStyle()
 )
+
+// themedStyles maps theme and config style names to the styles they color.
+func themedStyles() map[string]*lipgloss.Style {
+	return map[string]*lipgloss.Style{
+		"title":     &titleStyle,
+		"cursor":    &cursorStyle,
+		"checked":   &checkedStyle,
+		"rearrange": &rearrangeStyle,
+		"priority":  &priorityStyle,
+		"help":      &helpStyle,
+		"delete":    &deleteStyle,
+		"link":      &linkStyle,
+		"error":     &errorStyle,
+		"notice":    &noticeStyle,
+		"select":    &selectStyle,
+		"overdue":   &overdueStyle,
+		"due_today": &dueTodayStyle,
+		"upcoming":  &upcomingStyle,
+
+		"priority_high":   &priorityHighStyle,
+		"priority_medium": &priorityMediumStyle,
+		"priority_low":    &priorityLowStyle,
+		"tag":             &tagStyle,
+		"context":         &contextStyle,
+		"project":         &projectStyle,
+	}
+}
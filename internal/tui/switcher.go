@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxSwitcherResults caps how many matches the file switcher lists.
+const maxSwitcherResults = 50
+
+// filesIndexedMsg is returned by indexFilesCmd with the markdown files under root.
+type filesIndexedMsg struct {
+	root  string
+	files []string
+	err   error
+}
+
+// indexFilesCmd returns a tea.Cmd that lists markdown files under root.
+func indexFilesCmd(root string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := ListMarkdownFiles(root)
+		return filesIndexedMsg{root: root, files: files, err: err}
+	}
+}
+
+// switcherRootPath returns the directory the file switcher indexes: the
+// configured vault root, or the current file's directory.
+func (m model) switcherRootPath() string {
+	if m.config.VaultRoot != "" {
+		return ExpandHome(m.config.VaultRoot)
+	}
+	return filepath.Dir(m.file.Path)
+}
+
+// openSwitcher enters switcher mode and starts indexing the root directory.
+func (m model) openSwitcher() (tea.Model, tea.Cmd) {
+	m.mode = ModeSwitcher
+	m.switcherRoot = m.switcherRootPath()
+	m.switcherFiles = nil
+	m.switcherMatches = nil
+	m.switcherCursor = 0
+	m.switcherIndexing = true
+	m, inputCmd := m.startTextInput("")
+	return m, tea.Batch(inputCmd, indexFilesCmd(m.switcherRoot))
+}
+
+// handleFilesIndexed stores the file index for the open switcher.
+func (m model) handleFilesIndexed(msg filesIndexedMsg) (tea.Model, tea.Cmd) {
+	if m.mode != ModeSwitcher || msg.root != m.switcherRoot {
+		return m, nil
+	}
+	m.switcherIndexing = false
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.err)
+	}
+	m.switcherFiles = msg.files
+	m.refreshSwitcherMatches()
+	return m, nil
+}
+
+// refreshSwitcherMatches re-runs the fuzzy match for the current query.
+func (m *model) refreshSwitcherMatches() {
+	matches := FuzzyMatch(m.textInput.Value(), m.switcherFiles)
+	if len(matches) > maxSwitcherResults {
+		matches = matches[:maxSwitcherResults]
+	}
+	m.switcherMatches = matches
+	m.switcherCursor = min(m.switcherCursor, max(0, m.switcherOptionCount()-1))
+}
+
+// switcherCreatePath returns the relative path offered for creation, or ""
+// when the query is empty or already names an indexed file.
+func (m model) switcherCreatePath() string {
+	query := strings.TrimSpace(m.textInput.Value())
+	if query == "" {
+		return ""
+	}
+	if !markdownExtensions[strings.ToLower(filepath.Ext(query))] {
+		query += ".md"
+	}
+	for _, file := range m.switcherFiles {
+		if file == query {
+			return ""
+		}
+	}
+	return query
+}
+
+// switcherOptionCount returns the number of selectable rows: matches plus
+// the create option when offered.
+func (m model) switcherOptionCount() int {
+	count := len(m.switcherMatches)
+	if m.switcherCreatePath() != "" {
+		count++
+	}
+	return count
+}
+
+func (m model) updateSwitcher(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.textInput.Blur()
+		m.mode = ModeNormal
+		return m, nil
+	case key.Matches(msg, m.keys.NextMatch):
+		if m.switcherCursor < m.switcherOptionCount()-1 {
+			m.switcherCursor++
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.PrevMatch):
+		if m.switcherCursor > 0 {
+			m.switcherCursor--
+		}
+		return m, nil
+	case key.Matches(msg, m.keys.Confirm):
+		return m.confirmSwitcher()
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		m.refreshSwitcherMatches()
+		return m, cmd
+	}
+}
+
+// confirmSwitcher opens the selected file, or creates and links a new one.
+func (m model) confirmSwitcher() (tea.Model, tea.Cmd) {
+	if m.switcherOptionCount() == 0 {
+		return m, nil
+	}
+	m.textInput.Blur()
+	m.mode = ModeNormal
+
+	if m.switcherCursor >= len(m.switcherMatches) {
+		return m.createAndLinkFile(filepath.Join(m.switcherRoot, filepath.FromSlash(m.switcherCreatePath())))
+	}
+	return m.openFile(filepath.Join(m.switcherRoot, filepath.FromSlash(m.switcherMatches[m.switcherCursor])))
+}
+
+// openFile pushes the current file onto the navigation stack and loads path,
+// like following a link.
+func (m model) openFile(path string) (tea.Model, tea.Cmd) {
+	currentAbsolutePath, err := filepath.Abs(m.file.Path)
+	if err == nil {
+		targetAbsolutePath, err := filepath.Abs(path)
+		if err == nil && currentAbsolutePath == targetAbsolutePath {
+			return m, nil
+		}
+	}
+
+	if len(m.navStack) >= maxNavStackDepth {
+		m.statusMessage = fmt.Sprintf("Error: maximum navigation depth (%d) reached", maxNavStackDepth)
+		return m, nil
+	}
+
+	m.navStack = append(m.navStack, navigationEntry{
+		FilePath:       m.file.Path,
+		CursorPosition: m.cursor,
+	})
+	return m, loadFileCmd(path, -1)
+}
+
+// renderSwitcher renders the query input and matching files.
+func (m model) renderSwitcher() string {
+	var b strings.Builder
+	b.WriteString(" " + m.textInput.View())
+	b.WriteString("\n\n")
+
+	if m.switcherIndexing {
+		b.WriteString(helpStyle.Render("  Indexing " + m.switcherRoot + "…"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	visibleRows := m.switcherOptionCount()
+	if m.height > 0 {
+		visibleRows = min(visibleRows, max(1, m.height-m.listTop()-5))
+	}
+	start := max(0, m.switcherCursor-visibleRows+1)
+	end := min(m.switcherOptionCount(), start+visibleRows)
+
+	if m.switcherOptionCount() == 0 {
+		b.WriteString(helpStyle.Render("  No markdown files found."))
+		b.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		label := ""
+		if i < len(m.switcherMatches) {
+			label = m.switcherMatches[i]
+		} else {
+			label = fmt.Sprintf("+ create %s and link it here", m.switcherCreatePath())
+		}
+		if i == m.switcherCursor {
+			b.WriteString(cursorStyle.Render(" > " + label))
+		} else {
+			b.WriteString("   " + label)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
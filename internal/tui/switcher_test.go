@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openSwitcherWith opens the file switcher on m and types query.
+func openSwitcherWith(m model, query string) model {
+	m = update(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	for _, r := range query {
+		m = press(m, string(r))
+	}
+	return m
+}
+
+func TestConfirmSwitcher_OpensExistingFile(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n- [ ] two\n", DefaultConfig())
+	root := m.file.Path
+	dir := filepath.Dir(root)
+	if err := os.MkdirAll(filepath.Join(dir, "notes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeLinkedFile(t, m, "notes/plan.md", "- [ ] Draft\n")
+
+	m = press(openSwitcherWith(press(m, "j"), "plan"), "enter")
+	if want := filepath.Join(dir, "notes", "plan.md"); m.file.Path != want {
+		t.Fatalf("file = %s, want %s", m.file.Path, want)
+	}
+	if m.mode != ModeNormal {
+		t.Errorf("mode = %v, want normal", m.mode)
+	}
+	if len(m.navStack) != 1 || m.navStack[0].FilePath != root || m.navStack[0].CursorPosition != 1 {
+		t.Errorf("navStack = %+v, want the root at cursor 1", m.navStack)
+	}
+	data, _ := os.ReadFile(root)
+	if string(data) != "- [ ] one\n- [ ] two\n" {
+		t.Errorf("opening a file should not change the current one, got\n%s", data)
+	}
+}
+
+func TestConfirmSwitcher_CreatesAndLinksNewFile(t *testing.T) {
+	m := newTestModel(t, "- [ ] one\n", DefaultConfig())
+	root := m.file.Path
+	dir := filepath.Dir(root)
+
+	m = openSwitcherWith(m, "ideas")
+	if got := m.switcherCreatePath(); got != "ideas.md" {
+		t.Fatalf("switcherCreatePath = %q, want ideas.md", got)
+	}
+	m = press(m, "enter")
+
+	created := filepath.Join(dir, "ideas.md")
+	data, err := os.ReadFile(created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := RenderFileTemplate(DefaultFileTemplate, "ideas", m.now()); string(data) != want {
+		t.Errorf("new file =\n%s\nwant\n%s", data, want)
+	}
+	if m.file.Path != created {
+		t.Errorf("file = %s, want %s", m.file.Path, created)
+	}
+	data, _ = os.ReadFile(root)
+	if string(data) != "- [ ] one\n- [ ] todo:ideas.md\n" {
+		t.Errorf("root =\n%s\nwant a link to the new file", data)
+	}
+}
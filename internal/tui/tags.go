@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// styleLabels renders text in base with each #tag, @context, and +project
+// colored by its kind. Labels keep base's other attributes, such as the
+// strikethrough of checked items.
+func styleLabels(text string, base lipgloss.Style) string {
+	matches := labelRegex.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return base.Render(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		if start > last {
+			b.WriteString(base.Render(text[last:start]))
+		}
+		b.WriteString(labelStyle(text[start]).Inherit(base).Render(text[start:end]))
+		last = end
+	}
+	if last < len(text) {
+		b.WriteString(base.Render(text[last:]))
+	}
+	return b.String()
+}
+
+// labelStyle returns the style for a label starting with sigil.
+func labelStyle(sigil byte) lipgloss.Style {
+	switch sigil {
+	case '@':
+		return contextStyle
+	case '+':
+		return projectStyle
+	}
+	return tagStyle
+}
+
+// todoVisible reports whether the todo at todoIdx passes the tag filter: it
+// carries at least one of the filtered labels, or no filter is set.
+func (m model) todoVisible(todoIdx int) bool {
+	if len(m.tagFilter) == 0 {
+		return true
+	}
+	item := m.file.GetTodo(todoIdx)
+	for _, label := range m.tagFilter {
+		if item.HasTag(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleTodos returns the logical indexes of the todos passing the filter.
+func (m model) visibleTodos() []int {
+	var visible []int
+	for i := 0; i < m.file.TodoCount(); i++ {
+		if m.todoVisible(i) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// stepCursor returns the index of the next visible todo after the cursor
+// (step 1) or before it (step -1), or the cursor if there is none.
+func (m model) stepCursor(step int) int {
+	for i := m.cursor + step; i >= 0 && i < m.file.TodoCount(); i += step {
+		if m.todoVisible(i) {
+			return i
+		}
+	}
+	return m.cursor
+}
+
+// snapToVisible moves the cursor off a todo hidden by the tag filter, to the
+// next visible todo or else the previous one.
+func (m model) snapToVisible() model {
+	if m.file.TodoCount() == 0 || m.todoVisible(m.cursor) {
+		return m
+	}
+	if next := m.stepCursor(1); next != m.cursor {
+		m.cursor = next
+	} else {
+		m.cursor = m.stepCursor(-1)
+	}
+	return m
+}
+
+// renderTagFilter describes the active tag filter above the list.
+func (m model) renderTagFilter() string {
+	return helpStyle.Render(fmt.Sprintf("  Showing %s (%d of %d)", strings.Join(m.tagFilter, " or "), len(m.visibleTodos()), m.file.TodoCount()))
+}
+
+// openTagPicker lists the labels in the current file, with the filtered ones
+// selected.
+func (m model) openTagPicker() (tea.Model, tea.Cmd) {
+	items := make([]TodoItem, m.file.TodoCount())
+	for i := range items {
+		items[i] = m.file.GetTodo(i)
+	}
+	m.tagCounts = CountLabels(items)
+	if len(m.tagCounts) == 0 {
+		m.noticeMessage = "No #tags, @contexts, or +projects in this file"
+		return m, nil
+	}
+	m.tagPicked = map[string]bool{}
+	for _, label := range m.tagFilter {
+		m.tagPicked[strings.ToLower(label)] = true
+	}
+	m.tagCursor = 0
+	m.mode = ModeTagPicker
+	return m, nil
+}
+
+// updateTagPicker handles selecting labels to filter by. Confirming with
+// nothing selected clears the filter.
+func (m model) updateTagPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.tagCursor < len(m.tagCounts)-1 {
+			m.tagCursor++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.tagCursor > 0 {
+			m.tagCursor--
+		}
+	case key.Matches(msg, m.keys.Confirm):
+		m.tagFilter = nil
+		for _, count := range m.tagCounts {
+			if m.tagPicked[strings.ToLower(count.Label)] {
+				m.tagFilter = append(m.tagFilter, count.Label)
+			}
+		}
+		m.mode = ModeNormal
+		return m.snapToVisible(), nil
+	case key.Matches(msg, m.keys.Toggle), key.Matches(msg, m.keys.ToggleOnly):
+		label := strings.ToLower(m.tagCounts[m.tagCursor].Label)
+		m.tagPicked[label] = !m.tagPicked[label]
+	case key.Matches(msg, m.keys.Tags), key.Matches(msg, m.keys.Cancel):
+		m.mode = ModeNormal
+	}
+	return m, nil
+}
+
+// renderTagPicker lists the labels with their todo counts and checkboxes.
+func (m model) renderTagPicker() string {
+	var b strings.Builder
+	b.WriteString(helpStyle.Render("  Show todos with any of:"))
+	b.WriteString("\n\n")
+
+	visibleRows := len(m.tagCounts)
+	if m.height > 0 {
+		visibleRows = min(visibleRows, max(1, m.height-m.listTop()-5))
+	}
+	start := max(0, m.tagCursor-visibleRows+1)
+	end := min(len(m.tagCounts), start+visibleRows)
+
+	labelWidth := 0
+	for _, count := range m.tagCounts {
+		labelWidth = max(labelWidth, lipgloss.Width(count.Label))
+	}
+	for i := start; i < end; i++ {
+		count := m.tagCounts[i]
+		box := "[ ] "
+		if m.tagPicked[strings.ToLower(count.Label)] {
+			box = "[x] "
+		}
+		label := labelStyle(count.Label[0]).Render(padRight(count.Label, labelWidth))
+		countText := helpStyle.Render(fmt.Sprintf("  %d", count.Count))
+		if i == m.tagCursor {
+			b.WriteString(cursorStyle.Render(" > "+box) + label + countText)
+		} else {
+			b.WriteString("   " + box + label + countText)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// clearTagFilterOnSwitch drops the tag filter when a different file opens.
+func (m model) clearTagFilterOnSwitch(previousPath string) model {
+	if m.file.Path != previousPath {
+		m.tagFilter = nil
+	}
+	return m
+}
+
+// hidesTodos reports whether the tag filter hides any todo. Rearranging and
+// selecting work on neighboring todos, so they wait until it is cleared.
+func (m model) hidesTodos() bool {
+	return len(m.visibleTodos()) < m.file.TodoCount()
+}
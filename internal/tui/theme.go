@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme names accepted in the config file.
+const (
+	ThemeAuto         = "auto"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// palette assigns a color to each themed style name.
+type palette map[string]lipgloss.TerminalColor
+
+var darkPalette = palette{
+	"title":     lipgloss.Color("170"),
+	"cursor":    lipgloss.Color("212"),
+	"checked":   lipgloss.Color("240"),
+	"rearrange": lipgloss.Color("214"),
+	"priority":  lipgloss.Color("243"),
+	"help":      lipgloss.Color("241"),
+	"delete":    lipgloss.Color("196"),
+	"link":      lipgloss.Color("33"),
+	"error":     lipgloss.Color("196"),
+	"notice":    lipgloss.Color("35"),
+	"select":    lipgloss.Color("81"),
+	"overdue":   lipgloss.Color("196"),
+	"due_today": lipgloss.Color("214"),
+	"upcoming":  lipgloss.Color("37"),
+
+	"priority_high":   lipgloss.Color("203"),
+	"priority_medium": lipgloss.Color("221"),
+	"priority_low":    lipgloss.Color("75"),
+	"tag":             lipgloss.Color("141"),
+	"context":         lipgloss.Color("79"),
+	"project":         lipgloss.Color("180"),
+}
+
+var lightPalette = palette{
+	"title":     lipgloss.Color("127"),
+	"cursor":    lipgloss.Color("162"),
+	"checked":   lipgloss.Color("246"),
+	"rearrange": lipgloss.Color("166"),
+	"priority":  lipgloss.Color("244"),
+	"help":      lipgloss.Color("243"),
+	"delete":    lipgloss.Color("160"),
+	"link":      lipgloss.Color("25"),
+	"error":     lipgloss.Color("160"),
+	"notice":    lipgloss.Color("28"),
+	"select":    lipgloss.Color("31"),
+	"overdue":   lipgloss.Color("160"),
+	"due_today": lipgloss.Color("166"),
+	"upcoming":  lipgloss.Color("30"),
+
+	"priority_high":   lipgloss.Color("124"),
+	"priority_medium": lipgloss.Color("136"),
+	"priority_low":    lipgloss.Color("25"),
+	"tag":             lipgloss.Color("91"),
+	"context":         lipgloss.Color("29"),
+	"project":         lipgloss.Color("130"),
+}
+
+// highContrastPalette uses the basic 16 ANSI colors, which terminals tune
+// for legibility, with bright variants on dark backgrounds.
+var highContrastPalette = palette{
+	"title":     lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+	"cursor":    lipgloss.AdaptiveColor{Light: "4", Dark: "14"},
+	"checked":   lipgloss.AdaptiveColor{Light: "8", Dark: "7"},
+	"rearrange": lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+	"priority":  lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+	"help":      lipgloss.AdaptiveColor{Light: "0", Dark: "15"},
+	"delete":    lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+	"link":      lipgloss.AdaptiveColor{Light: "4", Dark: "12"},
+	"error":     lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+	"notice":    lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+	"select":    lipgloss.AdaptiveColor{Light: "5", Dark: "13"},
+	"overdue":   lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+	"due_today": lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+	"upcoming":  lipgloss.AdaptiveColor{Light: "6", Dark: "14"},
+
+	"priority_high":   lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+	"priority_medium": lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+	"priority_low":    lipgloss.AdaptiveColor{Light: "4", Dark: "12"},
+	"tag":             lipgloss.AdaptiveColor{Light: "5", Dark: "13"},
+	"context":         lipgloss.AdaptiveColor{Light: "6", Dark: "14"},
+	"project":         lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+}
+
+// autoPalette picks the light or dark color for each style based on the
+// terminal background.
+func autoPalette() palette {
+	auto := palette{}
+	for name, dark := range darkPalette {
+		auto[name] = lipgloss.AdaptiveColor{
+			Light: string(lightPalette[name].(lipgloss.Color)),
+			Dark:  string(dark.(lipgloss.Color)),
+		}
+	}
+	return auto
+}
+
+// themePalette returns the palette for a theme name. An empty name means auto.
+func themePalette(name string) (palette, error) {
+	switch name {
+	case "", ThemeAuto:
+		return autoPalette(), nil
+	case ThemeDark:
+		return darkPalette, nil
+	case ThemeLight:
+		return lightPalette, nil
+	case ThemeHighContrast:
+		return highContrastPalette, nil
+	}
+	return nil, fmt.Errorf("unknown theme: %s (expected auto, dark, light, or high-contrast)", name)
+}
+
+// validateColorOverrides reports override keys that do not name a themed style.
+func validateColorOverrides(overrides map[string]string) error {
+	styles := themedStyles()
+	var unknown []string
+	for name := range overrides {
+		if _, ok := styles[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown color style: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applyTheme colors the package styles from the named theme plus per-style
+// overrides (ANSI numbers or hex values). When NO_COLOR is set, colors are
+// dropped entirely and styles fall back to bold/underline/strikethrough.
+func applyTheme(name string, overrides map[string]string) error {
+	colors, err := themePalette(name)
+	if err != nil {
+		return err
+	}
+	if err := validateColorOverrides(overrides); err != nil {
+		return err
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return nil
+	}
+
+	for styleName, style := range themedStyles() {
+		color := colors[styleName]
+		if override, ok := overrides[styleName]; ok {
+			color = lipgloss.Color(override)
+		}
+		*style = style.Foreground(color)
+	}
+	return nil
+}
@@ -6,30 +6,34 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 const defaultFilePermission = 0644
 
 var todoRegex = regexp.MustCompile(`^(\s*- \[)([ xX])(\] )(.*)$`)
 
+// headingRegex matches an ATX markdown heading and captures its text
+// without any closing #s.
+var headingRegex = regexp.MustCompile(`^#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+
 // TodoItem represents a single parsed todo line.
 type TodoItem struct {
 	Text    string
 	Checked bool
 }
 
-// IsLinkedTodo returns true if the todo text starts with "todo:" prefix.
+// IsLinkedTodo returns true if the todo links to another file, either with
+// a "todo:" prefix or a wiki/markdown link anywhere in its text.
 func (item TodoItem) IsLinkedTodo() bool {
-	return strings.HasPrefix(item.Text, "todo:")
+	return item.Link().Kind != LinkNone
 }
 
-// LinkedPath returns the file path portion of a linked todo, trimmed of whitespace.
-// Returns empty string if the item is not a link or the path is empty.
+// LinkedPath returns the link target of a linked todo, trimmed of whitespace:
+// the path for todo: and markdown links, or the note name for wiki links.
+// Returns empty string if the item is not a link or the target is empty.
 func (item TodoItem) LinkedPath() string {
-	if !item.IsLinkedTodo() {
-		return ""
-	}
-	return strings.TrimSpace(strings.TrimPrefix(item.Text, "todo:"))
+	return item.Link().Target
 }
 
 // ResolveLinkedPath resolves a linked file path relative to the current file's directory.
@@ -47,6 +51,22 @@ type TodoFile struct {
 	Path        string
 	RawLines    []string
 	TodoIndices []int
+	Options     FileOptions
+}
+
+// FileOptions tune how a TodoFile edits todos.
+type FileOptions struct {
+	// RecurInPlace puts the fresh copy of a completed repeating todo on the
+	// original line and the checked one below it. By default the fresh copy
+	// goes right after the checked one.
+	RecurInPlace bool
+	// CompletionDates is the date marker style (DateMarkerEmoji or
+	// DateMarkerToken) stamped on todos when they are checked and removed
+	// when they are unchecked. Empty or DateMarkerNone stamps nothing.
+	CompletionDates string
+	// CreatedDates is the date marker style StampCreated writes on new todos,
+	// also used for the next copy of a repeating todo.
+	CreatedDates string
 }
 
 // ParseFile reads the file at path and returns a TodoFile.
@@ -112,6 +132,17 @@ func (tf *TodoFile) GetTodo(todoIdx int) TodoItem {
 	return *item
 }
 
+// Section returns the text of the nearest markdown heading above the todo at
+// logical index, without its leading #s, or "" if there is none.
+func (tf *TodoFile) Section(todoIdx int) string {
+	for i := tf.TodoIndices[todoIdx] - 1; i >= 0; i-- {
+		if matches := headingRegex.FindStringSubmatch(tf.RawLines[i]); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
+}
+
 // SetTodoText updates the text of a todo at logical index.
 func (tf *TodoFile) SetTodoText(todoIdx int, text string) {
 	lineIdx := tf.TodoIndices[todoIdx]
@@ -123,15 +154,71 @@ func (tf *TodoFile) SetTodoText(todoIdx int, text string) {
 	tf.RawLines[lineIdx] = FormatTodoLine(*item)
 }
 
-// ToggleTodo flips the checked state of a todo.
-func (tf *TodoFile) ToggleTodo(todoIdx int) {
+// ToggleTodo flips the checked state of a todo. Checking a repeating todo
+// also inserts an unchecked copy due on its next date, keeping the checked
+// line as a record; see FileOptions.RecurInPlace for where the copy goes.
+// The copy is not inserted again when the file already has it, e.g. after
+// unchecking and rechecking. With FileOptions.CompletionDates set, checking
+// stamps the completion time and unchecking removes it; a repeating todo is
+// always stamped, so its record shows when it was done.
+// It returns the logical index of the inserted copy, or -1 if none was.
+func (tf *TodoFile) ToggleTodo(todoIdx int) int {
 	lineIdx := tf.TodoIndices[todoIdx]
 	item := ParseTodoLine(tf.RawLines[lineIdx])
 	if item == nil {
-		return
+		return -1
 	}
+	now := time.Now()
 	item.Checked = !item.Checked
+	next, ok := item.NextOccurrence(now)
+	if style := completionStyle(*item, tf.Options.CompletionDates); stampsDates(style) {
+		stamp := now
+		if !item.Checked {
+			stamp = time.Time{}
+		}
+		*item = item.WithCompletion(style, stamp)
+	}
 	tf.RawLines[lineIdx] = FormatTodoLine(*item)
+	if !item.Checked || !ok {
+		return -1
+	}
+
+	next = next.WithCompletion(DateMarkerNone, time.Time{})
+	if tf.hasOpenTodo(next) {
+		return -1
+	}
+	if stampsDates(tf.Options.CreatedDates) {
+		next = next.WithCreated(tf.Options.CreatedDates, now)
+	}
+	insertAt, nextIdx := lineIdx+1, todoIdx+1
+	if tf.Options.RecurInPlace {
+		insertAt, nextIdx = lineIdx, todoIdx
+	}
+	tf.RawLines = slices.Insert(tf.RawLines, insertAt, FormatTodoLine(next))
+	tf.rebuildIndices()
+	return nextIdx
+}
+
+// completionStyle returns the completion marker style for item: the
+// configured style, or "✅" dates for a repeating todo when none is.
+func completionStyle(item TodoItem, configured string) string {
+	if stampsDates(configured) || item.Recurrence() == "" {
+		return configured
+	}
+	return DateMarkerEmoji
+}
+
+// hasOpenTodo reports whether the file has an unchecked todo matching item,
+// ignoring created markers.
+func (tf *TodoFile) hasOpenTodo(item TodoItem) bool {
+	want := item.WithCreated(DateMarkerNone, time.Time{}).Text
+	for i := 0; i < tf.TodoCount(); i++ {
+		other := tf.GetTodo(i)
+		if !other.Checked && other.WithCreated(DateMarkerNone, time.Time{}).Text == want {
+			return true
+		}
+	}
+	return false
 }
 
 // SwapTodos swaps two todo lines in RawLines by content.
@@ -169,6 +256,19 @@ func (tf *TodoFile) InsertTodo(afterTodoIdx int, item TodoItem) {
 	tf.rebuildIndices()
 }
 
+// InsertTodoBefore inserts a new todo directly above the given logical index.
+// If there are no todos or the index is out of range, it falls back to InsertTodo.
+func (tf *TodoFile) InsertTodoBefore(beforeTodoIdx int, item TodoItem) {
+	if beforeTodoIdx < 0 || beforeTodoIdx >= tf.TodoCount() {
+		tf.InsertTodo(-1, item)
+		return
+	}
+
+	insertAt := tf.TodoIndices[beforeTodoIdx]
+	tf.RawLines = slices.Insert(tf.RawLines, insertAt, FormatTodoLine(item))
+	tf.rebuildIndices()
+}
+
 // Save writes RawLines back to the file atomically.
 func (tf *TodoFile) Save() error {
 	content := strings.Join(tf.RawLines, "\n")
@@ -0,0 +1,256 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// treeBuiltMsg is returned by buildTreeCmd once the link hierarchy is parsed.
+type treeBuiltMsg struct {
+	root *LinkNode
+}
+
+// buildTreeCmd returns a tea.Cmd that builds the link tree from rootPath,
+// resolving links with resolver.
+func buildTreeCmd(rootPath string, resolver *LinkResolver) tea.Cmd {
+	return func() tea.Msg {
+		return treeBuiltMsg{root: BuildLinkTree(rootPath, maxNavStackDepth, resolver)}
+	}
+}
+
+// treeRow is one visible line of the tree outline.
+type treeRow struct {
+	depth int
+	// key identifies the row's position in the outline for expansion state,
+	// since the same file can appear under several parents.
+	key string
+	// node is the file containing the item, or the file itself for the root row.
+	node *LinkNode
+	// todoIdx is the item's logical index in node, or -1 for the root row.
+	todoIdx int
+	// child is the file this item links to, if any.
+	child *LinkNode
+	// ancestors are the navigation entries leading to node, root first.
+	ancestors []navigationEntry
+}
+
+// treeRootPath returns the file the tree starts from: the bottom of the
+// navigation stack, or the current file when nothing has been navigated.
+func (m model) treeRootPath() string {
+	if len(m.navStack) > 0 {
+		return m.navStack[0].FilePath
+	}
+	return m.file.Path
+}
+
+// handleTreeBuilt opens the tree view with the root file expanded.
+func (m model) handleTreeBuilt(msg treeBuiltMsg) (tea.Model, tea.Cmd) {
+	if msg.root.Err != nil {
+		m.statusMessage = fmt.Sprintf("Error: %v", msg.root.Err)
+		return m, nil
+	}
+	m.treeRoot = msg.root
+	m.treeExpanded = map[string]bool{"": true}
+	m.treeCursor = 0
+	m.mode = ModeTree
+	return m, nil
+}
+
+// treeRows flattens the expanded parts of the tree into visible rows.
+func (m model) treeRows() []treeRow {
+	if m.treeRoot == nil {
+		return nil
+	}
+	rows := []treeRow{{key: "", node: m.treeRoot, todoIdx: -1}}
+	if m.treeExpanded[""] {
+		rows = m.appendTreeItems(rows, m.treeRoot, "", 1, nil)
+	}
+	return rows
+}
+
+// appendTreeItems appends a row for each todo in node, recursing into
+// expanded linked items.
+func (m model) appendTreeItems(rows []treeRow, node *LinkNode, parentKey string, depth int, ancestors []navigationEntry) []treeRow {
+	if node.File == nil {
+		return rows
+	}
+	for i := 0; i < node.File.TodoCount(); i++ {
+		row := treeRow{
+			depth:     depth,
+			key:       fmt.Sprintf("%s/%d", parentKey, i),
+			node:      node,
+			todoIdx:   i,
+			child:     node.Children[i],
+			ancestors: ancestors,
+		}
+		rows = append(rows, row)
+		if row.child != nil && row.child.File != nil && m.treeExpanded[row.key] {
+			childAncestors := append(append([]navigationEntry{}, ancestors...), navigationEntry{
+				FilePath:       node.Path,
+				CursorPosition: i,
+			})
+			rows = m.appendTreeItems(rows, row.child, row.key, depth+1, childAncestors)
+		}
+	}
+	return rows
+}
+
+// expandable reports whether a row has children that can be shown.
+func (row treeRow) expandable() bool {
+	if row.todoIdx < 0 {
+		return true
+	}
+	return row.child != nil && !row.child.Cycle && row.child.File != nil
+}
+
+func (m model) updateTree(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.treeRows()
+	if m.treeCursor >= len(rows) {
+		m.treeCursor = len(rows) - 1
+	}
+	row := rows[m.treeCursor]
+
+	switch {
+	case key.Matches(msg, m.keys.Down):
+		if m.treeCursor < len(rows)-1 {
+			m.treeCursor++
+		}
+	case key.Matches(msg, m.keys.Up):
+		if m.treeCursor > 0 {
+			m.treeCursor--
+		}
+	case key.Matches(msg, m.keys.Expand):
+		if row.expandable() {
+			m.treeExpanded[row.key] = true
+		}
+	case key.Matches(msg, m.keys.Collapse):
+		if row.expandable() && m.treeExpanded[row.key] {
+			m.treeExpanded[row.key] = false
+		} else {
+			// Jump to the parent row
+			for i := m.treeCursor - 1; i >= 0; i-- {
+				if rows[i].depth < row.depth {
+					m.treeCursor = i
+					break
+				}
+			}
+		}
+	case key.Matches(msg, m.keys.Confirm):
+		return m.jumpToTreeRow(row)
+	case key.Matches(msg, m.keys.Tree, m.keys.Cancel, m.keys.Quit):
+		m.mode = ModeNormal
+		m.treeRoot = nil
+	}
+	return m, nil
+}
+
+// jumpToTreeRow opens the file for row and rebuilds the navigation stack from
+// the tree path, so going back walks up through each parent file.
+// Linked items open the linked file; other items open their own file at that item.
+func (m model) jumpToTreeRow(row treeRow) (tea.Model, tea.Cmd) {
+	targetPath := row.node.Path
+	targetCursor := max(row.todoIdx, 0)
+	stack := row.ancestors
+
+	if row.child != nil {
+		if row.child.Err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", row.child.Err)
+			return m, nil
+		}
+		targetPath = row.child.Path
+		targetCursor = 0
+		stack = append(append([]navigationEntry{}, row.ancestors...), navigationEntry{
+			FilePath:       row.node.Path,
+			CursorPosition: row.todoIdx,
+		})
+	}
+
+	if len(stack) > maxNavStackDepth {
+		m.statusMessage = fmt.Sprintf("Error: maximum navigation depth (%d) reached", maxNavStackDepth)
+		return m, nil
+	}
+
+	m.mode = ModeNormal
+	m.treeRoot = nil
+	return m, jumpToFileCmd(targetPath, targetCursor, stack)
+}
+
+// renderTree renders the visible window of the tree outline.
+func (m model) renderTree() string {
+	rows := m.treeRows()
+
+	visibleRows := len(rows)
+	if m.height > 0 {
+		visibleRows = max(1, m.height-m.listTop()-2)
+	}
+	start := max(0, m.treeCursor-visibleRows+1)
+	end := min(len(rows), start+visibleRows)
+
+	var b strings.Builder
+	for i := start; i < end; i++ {
+		b.WriteString(m.renderTreeRow(rows[i], i == m.treeCursor))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderTreeRow renders one outline row with its expansion marker.
+func (m model) renderTreeRow(row treeRow, isCursor bool) string {
+	cursor := "   "
+	if isCursor {
+		cursor = " > "
+	}
+	indent := strings.Repeat("  ", row.depth)
+
+	marker := "  "
+	switch {
+	case row.child != nil && row.child.Cycle:
+		marker = "↻ "
+	case row.child != nil && row.child.Err != nil:
+		marker = "✗ "
+	case row.expandable() && m.treeExpanded[row.key]:
+		marker = "▾ "
+	case row.expandable():
+		marker = "▸ "
+	}
+
+	if row.todoIdx < 0 {
+		text := fileBasenameWithoutExtension(row.node.Path)
+		if isCursor {
+			return cursorStyle.Render(cursor+indent+marker) + cursorStyle.Bold(true).Render(text)
+		}
+		return cursor + indent + marker + titleStyle.UnsetMarginBottom().Render(text)
+	}
+
+	item := row.node.File.GetTodo(row.todoIdx)
+	box := "[ ] "
+	if item.Checked {
+		box = "[x] "
+	}
+
+	label := item.DisplayText()
+	var text string
+	switch {
+	case isCursor:
+		textStyle := cursorStyle
+		if item.Checked {
+			textStyle = textStyle.Strikethrough(true)
+		}
+		if item.IsLinkedTodo() {
+			textStyle = textStyle.Underline(true)
+		}
+		return cursorStyle.Render(cursor+indent+marker+box) + textStyle.Render(label)
+	case item.IsLinkedTodo() && item.Checked:
+		text = linkStyle.Strikethrough(true).Render(label)
+	case item.IsLinkedTodo():
+		text = linkStyle.Render(label)
+	case item.Checked:
+		text = checkedStyle.Render(label)
+	default:
+		text = label
+	}
+	return cursor + indent + marker + box + text
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// markdownExtensions lists file extensions treated as todo files when indexing.
+var markdownExtensions = map[string]bool{
+	".md":       true,
+	".markdown": true,
+}
+
+// ListMarkdownFiles returns the markdown files under root as slash-separated
+// paths relative to root, sorted. Hidden directories such as .git and
+// .obsidian are skipped.
+func ListMarkdownFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !markdownExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		relativePath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relativePath))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ExpandHome replaces a leading "~" in path with the user's home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// FuzzyMatch returns the candidates containing every character of query in
+// order (case-insensitive), best matches first. Matches score higher for
+// consecutive characters, characters at word boundaries, and hits in the
+// file name rather than the directory. An empty query returns all candidates.
+func FuzzyMatch(query string, candidates []string) []string {
+	if query == "" {
+		return append([]string(nil), candidates...)
+	}
+
+	type scoredCandidate struct {
+		candidate string
+		score     int
+	}
+	var matches []scoredCandidate
+	for _, candidate := range candidates {
+		if score, ok := fuzzyScore(query, candidate); ok {
+			matches = append(matches, scoredCandidate{candidate, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return len(matches[i].candidate) < len(matches[j].candidate)
+	})
+
+	result := make([]string, len(matches))
+	for i, match := range matches {
+		result[i] = match.candidate
+	}
+	return result
+}
+
+// fuzzyScore scores query as an in-order subsequence of candidate.
+func fuzzyScore(query, candidate string) (int, bool) {
+	queryRunes := []rune(strings.ToLower(query))
+	candidateRunes := []rune(strings.ToLower(candidate))
+	baseNameStart := strings.LastIndex(candidate, "/") + 1
+
+	score := 0
+	queryIdx := 0
+	previousMatch := -2
+	for i, r := range candidateRunes {
+		if queryIdx == len(queryRunes) {
+			break
+		}
+		if r != queryRunes[queryIdx] {
+			continue
+		}
+		score++
+		if i == previousMatch+1 {
+			score += 3
+		}
+		if i == 0 || !unicode.IsLetter(candidateRunes[i-1]) && !unicode.IsDigit(candidateRunes[i-1]) {
+			score += 2
+		}
+		if len(string(candidateRunes[:i])) >= baseNameStart {
+			score++
+		}
+		previousMatch = i
+		queryIdx++
+	}
+	return score, queryIdx == len(queryRunes)
+}
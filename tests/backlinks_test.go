@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestFindBacklinks(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"home.md":           "- [ ] Plain\n- [ ] todo:work/tasks.md\n",
+		"notes/ideas.md":    "- [ ] Finish [[tasks]] first\n- [ ] [tasks](../work/tasks.md)\n",
+		"work/tasks.md":     "- [ ] todo:tasks.md\n- [ ] todo:../home.md\n",
+		"work/unrelated.md": "- [ ] todo:other.md\n",
+		".hidden/skip.md":   "- [ ] todo:../work/tasks.md\n",
+	})
+	target := filepath.Join(dir, "work", "tasks.md")
+
+	backlinks, err := tui.FindBacklinks(dir, target, tui.NewLinkResolver(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tui.Backlink{
+		{Path: filepath.Join(dir, "home.md"), TodoIndex: 1, Item: tui.TodoItem{Text: "todo:work/tasks.md"}},
+		{Path: filepath.Join(dir, "notes", "ideas.md"), TodoIndex: 0, Item: tui.TodoItem{Text: "Finish [[tasks]] first"}},
+		{Path: filepath.Join(dir, "notes", "ideas.md"), TodoIndex: 1, Item: tui.TodoItem{Text: "[tasks](../work/tasks.md)"}},
+	}
+	if len(backlinks) != len(want) {
+		t.Fatalf("got %d backlinks, want %d: %+v", len(backlinks), len(want), backlinks)
+	}
+	for i := range want {
+		if backlinks[i] != want[i] {
+			t.Errorf("backlink %d = %+v, want %+v", i, backlinks[i], want[i])
+		}
+	}
+
+	backlinks, err = tui.FindBacklinks(dir, filepath.Join(dir, "notes", "ideas.md"), tui.NewLinkResolver(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backlinks) != 0 {
+		t.Errorf("expected no backlinks, got %+v", backlinks)
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestBuildLinkGraph(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":       "- [ ] todo:work/tasks.md\n- [x] [[notes]]\n- [ ] todo:missing.md\n",
+		"work/tasks.md": "- [ ] [notes](../notes.md)\n",
+		"notes.md":      "- [ ] Plain\n",
+	})
+
+	graph := tui.BuildLinkGraph(filepath.Join(dir, "root.md"), tui.NewLinkResolver(dir))
+	if len(graph.Nodes) != 4 {
+		t.Fatalf("expected 4 nodes, got %d", len(graph.Nodes))
+	}
+	if len(graph.Edges) != 4 {
+		t.Fatalf("expected 4 edges, got %d", len(graph.Edges))
+	}
+
+	notes := graph.Node(filepath.Join(dir, "notes.md"))
+	if notes == nil || notes.Depth != 1 {
+		t.Fatalf("expected notes.md at depth 1, got %+v", notes)
+	}
+	if missing := graph.Node(filepath.Join(dir, "missing.md")); missing == nil || missing.Err == nil {
+		t.Errorf("expected missing.md node with an error, got %+v", missing)
+	}
+	edges := graph.OutEdges(filepath.Join(dir, "root.md"))
+	if len(edges) != 3 || !edges[1].Item.Checked || edges[1].To != notes.Path {
+		t.Errorf("unexpected root edges: %+v", edges)
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":         "# Root\n\n- [ ] todo:a.md\n- [ ] todo:gone.md\n",
+		"a.md":            "- [ ] todo:b.md\n- [ ] todo:a.md\n",
+		"b.md":            "- [ ] Plain\n- [ ] [[root]]\n",
+		"orphan.md":       "- [ ] Nobody links here\n",
+		".hidden/skip.md": "- [ ] Skipped\n",
+	})
+
+	issues, err := tui.CheckLinks(filepath.Join(dir, "root.md"), dir, tui.NewLinkResolver(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []tui.Issue{
+		{Kind: tui.IssueBrokenLink, Path: filepath.Join(dir, "root.md"), Line: 4, Message: "link to gone.md: file not found"},
+		{Kind: tui.IssueCycle, Path: filepath.Join(dir, "b.md"), Line: 2, Message: "root.md → a.md → b.md → root.md"},
+		{Kind: tui.IssueCycle, Path: filepath.Join(dir, "a.md"), Line: 2, Message: "links to itself"},
+		{Kind: tui.IssueOrphan, Path: filepath.Join(dir, "orphan.md"), Message: "not linked from root.md"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for i := range want {
+		if issues[i] != want[i] {
+			t.Errorf("issue %d = %+v, want %+v", i, issues[i], want[i])
+		}
+	}
+}
+
+func TestCheckLinks_TooDeep(t *testing.T) {
+	files := map[string]string{}
+	const chainLength = 55
+	for i := 0; i < chainLength; i++ {
+		files[fmt.Sprintf("%d.md", i)] = fmt.Sprintf("- [ ] todo:%d.md\n", i+1)
+	}
+	files[fmt.Sprintf("%d.md", chainLength)] = "- [ ] Leaf\n"
+	dir := writeTodoTree(t, files)
+
+	issues, err := tui.CheckLinks(filepath.Join(dir, "0.md"), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Kind != tui.IssueTooDeep || issues[0].Path != filepath.Join(dir, "51.md") {
+		t.Errorf("expected one too-deep issue at 51.md, got %+v", issues)
+	}
+}
+
+func TestCheckLinks_MissingRoot(t *testing.T) {
+	if _, err := tui.CheckLinks(filepath.Join(t.TempDir(), "nope.md"), "", nil); err == nil {
+		t.Error("expected an error for a missing root file")
+	}
+}
@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestCompletedAt(t *testing.T) {
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"Ship it ✅ 2026-10-16", time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local), true},
+		{"Ship it done:2026-10-16", time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local), true},
+		{"Ship it done:2026-10-16T14:03 #work", time.Date(2026, 10, 16, 14, 3, 0, 0, time.Local), true},
+		{"Ship it done:soon", time.Time{}, false},
+		{"Ship it", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := tui.TodoItem{Text: tt.text}.CompletedAt()
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("CompletedAt(%q) = %v, %v; want %v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCreatedAt(t *testing.T) {
+	want := time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local)
+	for _, text := range []string{"Plan ➕ 2026-10-02", "Plan created:2026-10-02 due:2026-10-20"} {
+		if got, ok := (tui.TodoItem{Text: text}).CreatedAt(); !ok || !got.Equal(want) {
+			t.Errorf("CreatedAt(%q) = %v, %v; want %v", text, got, ok, want)
+		}
+	}
+}
+
+func TestDisplayText_HidesDateMarkers(t *testing.T) {
+	item := tui.TodoItem{Text: "Ship it ➕ 2026-10-02 ✅ 2026-10-16"}
+	if got := item.DisplayText(); got != "Ship it" {
+		t.Errorf("DisplayText() = %q, want %q", got, "Ship it")
+	}
+}
+
+func TestWithCompletion(t *testing.T) {
+	at := time.Date(2026, 10, 16, 14, 3, 0, 0, time.Local)
+	tests := []struct {
+		text  string
+		style string
+		at    time.Time
+		want  string
+	}{
+		{"Ship it", tui.DateMarkerEmoji, at, "Ship it ✅ 2026-10-16"},
+		{"Ship it", tui.DateMarkerToken, at, "Ship it done:2026-10-16T14:03"},
+		{"Ship it ✅ 2026-10-01", tui.DateMarkerToken, at, "Ship it done:2026-10-16T14:03"},
+		{"Ship it done:2026-10-01T09:00 #work", tui.DateMarkerNone, time.Time{}, "Ship it #work"},
+	}
+	for _, tt := range tests {
+		if got := (tui.TodoItem{Text: tt.text}).WithCompletion(tt.style, tt.at).Text; got != tt.want {
+			t.Errorf("WithCompletion(%q, %s) = %q, want %q", tt.text, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestToggleTodo_CompletionDates(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [ ] Ship it\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.Options = tui.FileOptions{CompletionDates: tui.DateMarkerEmoji}
+
+	tf.ToggleTodo(0)
+	want := "- [x] Ship it ✅ " + time.Now().Format("2006-01-02")
+	if tf.RawLines[0] != want {
+		t.Errorf("after checking: %q, want %q", tf.RawLines[0], want)
+	}
+	tf.ToggleTodo(0)
+	if tf.RawLines[0] != "- [ ] Ship it" {
+		t.Errorf("after unchecking: %q, want %q", tf.RawLines[0], "- [ ] Ship it")
+	}
+}
+
+func TestToggleTodo_CompletionDatesOff(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [x] Ship it ✅ 2026-10-16\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.ToggleTodo(0)
+	if tf.RawLines[0] != "- [ ] Ship it ✅ 2026-10-16" {
+		t.Errorf("unchecking without the option changed the text: %q", tf.RawLines[0])
+	}
+}
+
+func TestToggleTodo_RecurringCopyHasNoCompletion(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [ ] Trash every:week due:2026-10-20\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.Options = tui.FileOptions{CompletionDates: tui.DateMarkerToken}
+
+	nextIdx := tf.ToggleTodo(0)
+	if got := tf.GetTodo(nextIdx).Text; got != "Trash every:week due:2026-10-27" {
+		t.Errorf("next copy = %q", got)
+	}
+	if _, ok := tf.GetTodo(0).CompletedAt(); !ok {
+		t.Errorf("checked todo has no completion date: %q", tf.GetTodo(0).Text)
+	}
+}
+
+func TestStampCreated(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [ ] First\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 14, 3, 0, 0, time.Local)
+	tests := []struct {
+		style string
+		text  string
+		want  string
+	}{
+		{tui.DateMarkerNone, "Plan", "Plan"},
+		{tui.DateMarkerEmoji, "Plan", "Plan ➕ 2026-10-16"},
+		{tui.DateMarkerToken, "Plan #work", "Plan #work created:2026-10-16"},
+		{tui.DateMarkerToken, "Plan ➕ 2026-01-01", "Plan ➕ 2026-01-01"},
+	}
+	for _, tt := range tests {
+		tf.Options = tui.FileOptions{CreatedDates: tt.style}
+		if got := tf.StampCreated(tui.TodoItem{Text: tt.text}, now).Text; got != tt.want {
+			t.Errorf("StampCreated(%q) with %s = %q, want %q", tt.text, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestInsertTodo_KeepsCreatedDates(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [ ] First\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.Options = tui.FileOptions{CreatedDates: tui.DateMarkerToken}
+
+	tf.InsertTodo(0, tui.TodoItem{Text: "Pasted created:2026-01-01"})
+	tf.InsertTodoBefore(0, tui.TodoItem{Text: "Pasted without a date"})
+	want := []string{"Pasted without a date", "First", "Pasted created:2026-01-01"}
+	for i, text := range want {
+		if got := tf.GetTodo(i).Text; got != text {
+			t.Errorf("todo %d = %q, want %q", i, got, text)
+		}
+	}
+}
+
+func TestLink_IgnoresDateMarkers(t *testing.T) {
+	item := tui.TodoItem{Text: "todo:projects/work.md created:2026-10-02 done:2026-10-16T14:03"}
+	if got := item.LinkedPath(); got != "projects/work.md" {
+		t.Errorf("LinkedPath() = %q, want %q", got, "projects/work.md")
+	}
+	if strings.Contains(item.DisplayText(), "2026") {
+		t.Errorf("DisplayText() = %q, want the dates hidden", item.DisplayText())
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig_MissingFile(t *testing.T) {
+	config, err := tui.LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected no error for missing config, got %v", err)
+	}
+	if config.Clipboard != tui.ClipboardNone {
+		t.Errorf("expected default clipboard %q, got %q", tui.ClipboardNone, config.Clipboard)
+	}
+}
+
+func TestLoadConfig_Keys(t *testing.T) {
+	path := writeConfigFile(t, `{"clipboard": "osc52", "keys": {"quit": ["Q"], "toggle": ["space"]}}`)
+	config, err := tui.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Clipboard != tui.ClipboardOSC52 {
+		t.Errorf("expected clipboard %q, got %q", tui.ClipboardOSC52, config.Clipboard)
+	}
+	if got := config.Keys["quit"]; len(got) != 1 || got[0] != "Q" {
+		t.Errorf("expected quit keys [Q], got %v", got)
+	}
+}
+
+func TestLoadConfig_Theme(t *testing.T) {
+	path := writeConfigFile(t, `{"theme": "high-contrast", "colors": {"link": "#5f87ff", "checked": "244"}}`)
+	config, err := tui.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Theme != tui.ThemeHighContrast {
+		t.Errorf("expected theme %q, got %q", tui.ThemeHighContrast, config.Theme)
+	}
+	if config.Colors["link"] != "#5f87ff" {
+		t.Errorf("expected link override, got %q", config.Colors["link"])
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"unknown action", `{"keys": {"launch": ["l"]}}`, "unknown key action"},
+		{"empty keys", `{"keys": {"quit": []}}`, "no keys given"},
+		{"bad clipboard", `{"clipboard": "fax"}`, "invalid clipboard mode"},
+		{"malformed json", `{"keys": `, "parsing"},
+		{"unknown theme", `{"theme": "solarized"}`, "unknown theme"},
+		{"unknown color style", `{"colors": {"banner": "170"}}`, "unknown color style"},
+		{"bad recurrence", `{"recurrence": "before"}`, "invalid recurrence placement"},
+		{"bad completion dates", `{"completion_dates": "iso"}`, "invalid completion_dates style"},
+		{"key bound twice", `{"keys": {"delete": ["x"]}}`, `key "x" is bound to both toggle_only and delete in normal mode`},
+		{"key bound twice in query", `{"keys": {"confirm": ["x"]}}`, `key "x" is bound to both confirm and toggle_only in query mode`},
+		{"key bound twice in help", `{"keys": {"cancel": ["?"]}}`, `key "?" is bound to both help and cancel in help mode`},
+	}
+
+	for _, tt := range tests {
+		_, err := tui.LoadConfig(writeConfigFile(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.errText) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.errText, err)
+		}
+	}
+}
+
+func TestConfigPath_XDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := tui.ConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/tmp/xdg/jeb-todo-md/config.json" {
+		t.Errorf("expected XDG config path, got %q", path)
+	}
+}
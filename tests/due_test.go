@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestDueDate_Syntaxes(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Pay rent due:2026-10-20", "2026-10-20"},
+		{"Pay rent 📅 2026-10-20 #home", "2026-10-20"},
+		{"Pay rent 📅2026-10-20", "2026-10-20"},
+		{"Pay rent overdue:2026-10-20", ""},
+		{"Pay rent", ""},
+	}
+	for _, tt := range tests {
+		due, ok := tui.TodoItem{Text: tt.text}.DueDate()
+		got := ""
+		if ok {
+			got = due.Format("2006-01-02")
+		}
+		if got != tt.want {
+			t.Errorf("DueDate(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayText_HidesDueDate(t *testing.T) {
+	tests := map[string]string{
+		"Pay rent due:2026-10-20":              "Pay rent",
+		"Pay rent 📅 2026-10-20 #home":          "Pay rent #home",
+		"due:2026-10-20 Review [[Plan]]":       "Review Plan",
+		"Pay rent overdue:2026-10-20 reminder": "Pay rent overdue:2026-10-20 reminder",
+	}
+	for text, want := range tests {
+		if got := (tui.TodoItem{Text: text}).DisplayText(); got != want {
+			t.Errorf("DisplayText(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestWithDueDate(t *testing.T) {
+	due := time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		text string
+		due  time.Time
+		want string
+	}{
+		{"Pay rent", due, "Pay rent due:2026-11-02"},
+		{"Pay rent due:2026-10-20 #home", due, "Pay rent due:2026-11-02 #home"},
+		{"Pay rent 📅 2026-10-20", due, "Pay rent 📅 2026-11-02"},
+		{"Pay rent due:2026-10-20 #home", time.Time{}, "Pay rent #home"},
+		{"Pay rent", time.Time{}, "Pay rent"},
+	}
+	for _, tt := range tests {
+		item := tui.TodoItem{Text: tt.text, Checked: true}.WithDueDate(tt.due)
+		if item.Text != tt.want || !item.Checked {
+			t.Errorf("WithDueDate(%q) = %+v, want text %q", tt.text, item, tt.want)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	// Sunday afternoon.
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.Local)
+	tests := map[string]string{
+		"today":      "2026-10-18",
+		"Tomorrow":   "2026-10-19",
+		"yesterday":  "2026-10-17",
+		"+3d":        "2026-10-21",
+		"+2w":        "2026-11-01",
+		"+1m":        "2026-11-18",
+		"+13m":       "2027-11-18",
+		"fri":        "2026-10-23",
+		"sunday":     "2026-10-25",
+		"2027-01-05": "2027-01-05",
+	}
+	for input, want := range tests {
+		got, err := tui.ParseDate(input, now)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", input, err)
+			continue
+		}
+		if got.Format("2006-01-02") != want || got.Hour() != 0 {
+			t.Errorf("ParseDate(%q) = %v, want %s at midnight", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "soon", "+3x", "2026-13-01"} {
+		if _, err := tui.ParseDate(input, now); err == nil {
+			t.Errorf("ParseDate(%q): expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func buildExportGraph(t *testing.T) (*tui.LinkGraph, string) {
+	t.Helper()
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":   "- [ ] todo:work/a.md\n- [x] todo:work/b.md\n- [ ] todo:gone.md\n",
+		"work/a.md": "- [ ] Open\n- [x] Done\n",
+		"work/b.md": "- [x] Say \"hi\"\n",
+	})
+	return tui.BuildLinkGraph(filepath.Join(dir, "root.md"), nil), dir
+}
+
+func TestFormatGraph_DOT(t *testing.T) {
+	graph, dir := buildExportGraph(t)
+	got, err := tui.FormatGraph(graph, dir, tui.GraphFormatDOT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph todos {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="root.md\n2 open, 1 done"];
+  n1 [label="work/a.md\n1 open, 1 done"];
+  n2 [label="work/b.md\n0 open, 1 done"];
+  n3 [label="gone.md\nmissing", style=dashed];
+  n0 -> n1;
+  n0 -> n2 [style=dashed, color=gray];
+  n0 -> n3;
+}
+`
+	if got != want {
+		t.Errorf("DOT output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatGraph_Mermaid(t *testing.T) {
+	graph, dir := buildExportGraph(t)
+	got, err := tui.FormatGraph(graph, dir, tui.GraphFormatMermaid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `graph LR
+  n0["root.md<br/>2 open, 1 done"]
+  n1["work/a.md<br/>1 open, 1 done"]
+  n2["work/b.md<br/>0 open, 1 done"]
+  n3["gone.md<br/>missing"]
+  n0 --> n1
+  n0 -.-> n2
+  n0 --> n3
+  classDef missing stroke-dasharray: 5 5
+  class n3 missing
+`
+	if got != want {
+		t.Errorf("Mermaid output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatGraph_UnknownFormat(t *testing.T) {
+	graph, dir := buildExportGraph(t)
+	if _, err := tui.FormatGraph(graph, dir, "svg"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
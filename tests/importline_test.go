@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestParseInputLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   tui.TodoItem
+		wantOK bool
+	}{
+		{"Buy milk", tui.TodoItem{Text: "Buy milk"}, true},
+		{"  src/main.go:12: // TODO: tidy  ", tui.TodoItem{Text: "src/main.go:12: // TODO: tidy"}, true},
+		{"- [ ] Open item", tui.TodoItem{Text: "Open item"}, true},
+		{"  - [x] Done item", tui.TodoItem{Text: "Done item", Checked: true}, true},
+		{"- Bulleted", tui.TodoItem{Text: "Bulleted"}, true},
+		{"* Starred", tui.TodoItem{Text: "Starred"}, true},
+		{"x 2024-01-02 2024-01-01 Call mom", tui.TodoItem{Text: "Call mom ➕ 2024-01-01 ✅ 2024-01-02", Checked: true}, true},
+		{"x 2026-01-02 2026-01-01 task", tui.TodoItem{Text: "task ➕ 2026-01-01 ✅ 2026-01-02", Checked: true}, true},
+		{"x 2024-01-02 (A) Pay rent", tui.TodoItem{Text: "(A) Pay rent ✅ 2024-01-02", Checked: true}, true},
+		{"(B) 2024-01-01 Write report +work", tui.TodoItem{Text: "(B) Write report +work ➕ 2024-01-01"}, true},
+		{"(C) No date", tui.TodoItem{Text: "(C) No date"}, true},
+		{"2024-01-01 Dated", tui.TodoItem{Text: "Dated ➕ 2024-01-01"}, true},
+		{"x marks the spot", tui.TodoItem{Text: "x marks the spot"}, true},
+		{"   ", tui.TodoItem{}, false},
+		{"- [ ] ", tui.TodoItem{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := tui.ParseInputLine(tt.line)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("ParseInputLine(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseInputLine_KeepsTodoTxtDatesWhenStamped(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [ ] First\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.Options = tui.FileOptions{CreatedDates: tui.DateMarkerToken}
+	item, _ := tui.ParseInputLine("x 2026-01-02 2026-01-01 task")
+	item = tf.StampCreated(item, time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local))
+
+	if created, ok := item.CreatedAt(); !ok || !created.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("CreatedAt = %v, %v; want 2026-01-01", created, ok)
+	}
+	if done, ok := item.CompletedAt(); !ok || !done.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("CompletedAt = %v, %v; want 2026-01-02", done, ok)
+	}
+}
@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestLink(t *testing.T) {
+	tests := []struct {
+		text string
+		want tui.Link
+	}{
+		{"todo:work/tasks.md", tui.Link{Kind: tui.LinkTodo, Target: "work/tasks.md", Label: "work/tasks"}},
+		{"todo:work/tasks.md #work @office +launch", tui.Link{Kind: tui.LinkTodo, Target: "work/tasks.md", Label: "work/tasks"}},
+		{"todo:work/tasks.md #work due:2026-10-20 @office", tui.Link{Kind: tui.LinkTodo, Target: "work/tasks.md", Label: "work/tasks"}},
+		{"todo:work/tasks.md every:week", tui.Link{Kind: tui.LinkTodo, Target: "work/tasks.md", Label: "work/tasks"}},
+		{"todo:work/tasks.md 🔁 every 2 weeks 📅 2026-10-20", tui.Link{Kind: tui.LinkTodo, Target: "work/tasks.md", Label: "work/tasks"}},
+		{"todo:#inbox.md", tui.Link{Kind: tui.LinkTodo, Target: "#inbox.md", Label: "#inbox"}},
+		{"todo:Project #1.md #work", tui.Link{Kind: tui.LinkTodo, Target: "Project #1.md", Label: "Project #1"}},
+		{"todo:a +b.md +launch", tui.Link{Kind: tui.LinkTodo, Target: "a +b.md", Label: "a +b"}},
+		{"todo:people/@ana.md @office", tui.Link{Kind: tui.LinkTodo, Target: "people/@ana.md", Label: "people/@ana"}},
+		{"(A) todo:work.md", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"!!! todo:work.md", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"! todo:work.md", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"⏫ todo:work.md", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"🔽 todo:work.md", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"todo:work.md !!", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"todo:work.md 🔼", tui.Link{Kind: tui.LinkTodo, Target: "work.md", Label: "work"}},
+		{"Plan [[Project X]] soon", tui.Link{Kind: tui.LinkWiki, Target: "Project X", Label: "Project X"}},
+		{"[[notes/Ideas#Later|ideas]]", tui.Link{Kind: tui.LinkWiki, Target: "notes/Ideas", Label: "ideas"}},
+		{"See [the plan](plans/q3%20plan.md) today", tui.Link{Kind: tui.LinkMarkdown, Target: "plans/q3 plan.md", Label: "the plan"}},
+		{"Read [docs](https://example.com/a.md) then [local](b.md)", tui.Link{Kind: tui.LinkMarkdown, Target: "b.md", Label: "local"}},
+		{"Read [docs](https://example.com/a.md)", tui.Link{}},
+		{"Open [site](https://example.com)", tui.Link{}},
+		{"not a todo:link", tui.Link{}},
+	}
+	for _, tt := range tests {
+		got := tui.TodoItem{Text: tt.text}.Link()
+		if got != tt.want {
+			t.Errorf("Link(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Plan [[Project X]] soon", "Plan Project X soon"},
+		{"[[Ideas|my ideas]] and [spec](spec.md)", "my ideas and spec"},
+		{"[](notes/today.md)", "today"},
+		{"Open [site](https://example.com/a.md)", "Open [site](https://example.com/a.md)"},
+		{"todo:work.md", "todo:work.md"},
+		{"Water plants every:3d", "Water plants"},
+		{"Plants 🔁 every monday #home", "Plants #home"},
+	}
+	for _, tt := range tests {
+		got := tui.TodoItem{Text: tt.text}.DisplayText()
+		if got != tt.want {
+			t.Errorf("DisplayText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLinkResolverResolve(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":               "",
+		"local.md":              "",
+		"projects/Project X.md": "",
+		"projects/sub/deep.md":  "",
+		"other/local.md":        "",
+	})
+	rootPath := filepath.Join(dir, "root.md")
+	resolver := tui.NewLinkResolver(dir)
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"todo:projects/sub/deep.md", filepath.Join(dir, "projects/sub/deep.md")},
+		{"[deep](projects/sub/deep.md)", filepath.Join(dir, "projects/sub/deep.md")},
+		{"[[project x]]", filepath.Join(dir, "projects/Project X.md")},
+		{"[[local]]", filepath.Join(dir, "local.md")},
+		{"[[sub/deep]]", filepath.Join(dir, "sub/deep.md")},
+		{"[[projects/sub/deep]]", filepath.Join(dir, "projects/sub/deep.md")},
+		{"[[Missing]]", filepath.Join(dir, "Missing.md")},
+		{"Plain item", ""},
+	}
+	for _, tt := range tests {
+		got := resolver.Resolve(rootPath, tui.TodoItem{Text: tt.text})
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	var noVault *tui.LinkResolver
+	if got, want := noVault.Resolve(rootPath, tui.TodoItem{Text: "[[Project X]]"}), filepath.Join(dir, "Project X.md"); got != want {
+		t.Errorf("nil resolver Resolve = %q, want %q", got, want)
+	}
+}
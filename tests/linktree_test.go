@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+// writeTodoTree writes each file in files (relative path -> content) under a temp dir
+// and returns the directory.
+func writeTodoTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for relativePath, content := range files {
+		path := filepath.Join(dir, relativePath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBuildLinkTree(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":       "- [ ] Plain\n- [ ] todo:work/tasks.md\n- [ ] todo:missing.md\n",
+		"work/tasks.md": "- [x] Ship it\n- [ ] todo:../root.md\n",
+	})
+
+	root := tui.BuildLinkTree(filepath.Join(dir, "root.md"), 10, nil)
+	if root.Err != nil {
+		t.Fatal(root.Err)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("expected 2 linked children, got %d", len(root.Children))
+	}
+
+	work := root.Children[1]
+	if work == nil || work.File == nil {
+		t.Fatal("expected work/tasks.md to be parsed")
+	}
+	if work.File.TodoCount() != 2 {
+		t.Errorf("expected 2 todos in work/tasks.md, got %d", work.File.TodoCount())
+	}
+
+	backToRoot := work.Children[1]
+	if backToRoot == nil || !backToRoot.Cycle {
+		t.Error("expected link back to root.md to be marked as a cycle")
+	}
+
+	missing := root.Children[2]
+	if missing == nil || missing.Err == nil {
+		t.Error("expected missing.md to carry an error")
+	}
+}
+
+func TestBuildLinkTree_MaxDepth(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"a.md": "- [ ] todo:b.md\n",
+		"b.md": "- [ ] todo:c.md\n",
+		"c.md": "- [ ] Leaf\n",
+	})
+
+	root := tui.BuildLinkTree(filepath.Join(dir, "a.md"), 1, nil)
+	b := root.Children[0]
+	if b == nil || b.File == nil {
+		t.Fatal("expected b.md to be parsed within depth")
+	}
+	if len(b.Children) != 0 {
+		t.Errorf("expected no children beyond max depth, got %d", len(b.Children))
+	}
+}
+
+func TestBuildLinkTree_ParsesSharedFileOnce(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"a.md": "- [ ] todo:b.md\n- [ ] todo:c.md\n",
+		"b.md": "- [ ] todo:d.md\n",
+		"c.md": "- [ ] todo:d.md\n",
+		"d.md": "- [ ] Leaf\n",
+	})
+
+	root := tui.BuildLinkTree(filepath.Join(dir, "a.md"), 10, nil)
+	viaB := root.Children[0].Children[0]
+	viaC := root.Children[1].Children[0]
+	if viaB == nil || viaC == nil || viaB.File == nil {
+		t.Fatal("expected d.md under both b.md and c.md")
+	}
+	if viaB.File != viaC.File {
+		t.Error("expected d.md to be parsed once and shared by both nodes")
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestPriority(t *testing.T) {
+	tests := map[string]tui.Priority{
+		"(A) Call mom":            tui.PriorityHigh,
+		"(B) Call mom":            tui.PriorityMedium,
+		"(D) Call mom":            tui.PriorityLow,
+		"Call mom (A)":            tui.PriorityNone,
+		"Call mom !!!":            tui.PriorityHigh,
+		"!! Call mom":             tui.PriorityMedium,
+		"Call ! mom":              tui.PriorityLow,
+		"Call mom!":               tui.PriorityNone,
+		"Call mom !!!!":           tui.PriorityNone,
+		"Call mom ⏫ 📅 2026-10-20": tui.PriorityHigh,
+		"Call mom 🔼":              tui.PriorityMedium,
+		"Call mom 🔽":              tui.PriorityLow,
+		"Call mom":                tui.PriorityNone,
+	}
+	for text, want := range tests {
+		if got := (tui.TodoItem{Text: text}).Priority(); got != want {
+			t.Errorf("Priority(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestWithPriority(t *testing.T) {
+	tests := []struct {
+		text     string
+		priority tui.Priority
+		want     string
+	}{
+		{"Call mom", tui.PriorityHigh, "(A) Call mom"},
+		{"(A) Call mom", tui.PriorityMedium, "(B) Call mom"},
+		{"(A) Call mom", tui.PriorityNone, "Call mom"},
+		{"Call mom ! today", tui.PriorityHigh, "Call mom !!! today"},
+		{"Call mom !! today", tui.PriorityNone, "Call mom today"},
+		{"Call mom 🔽 📅 2026-10-20", tui.PriorityMedium, "Call mom 🔼 📅 2026-10-20"},
+		{"Call mom ⏫ 📅 2026-10-20", tui.PriorityNone, "Call mom 📅 2026-10-20"},
+		{"Call mom", tui.PriorityNone, "Call mom"},
+	}
+	for _, tt := range tests {
+		if got := (tui.TodoItem{Text: tt.text}).WithPriority(tt.priority).Text; got != tt.want {
+			t.Errorf("WithPriority(%q, %d) = %q, want %q", tt.text, tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayText_HidesPriority(t *testing.T) {
+	tests := map[string]string{
+		"(A) Call mom":              "Call mom",
+		"Call mom !!! today":        "Call mom today",
+		"Call mom ⏫ due:2026-10-20": "Call mom",
+		"Call mom! (A)":             "Call mom! (A)",
+	}
+	for text, want := range tests {
+		if got := (tui.TodoItem{Text: text}).DisplayText(); got != want {
+			t.Errorf("DisplayText(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSortBySection(t *testing.T) {
+	path := writeTempFile(t, `# Work
+- [ ] plain
+- [ ] dated later due:2026-11-01
+- [ ] low !
+note stays here
+- [ ] dated sooner due:2026-10-20
+- [ ] (A) urgent
+
+## Home
+- [ ] b
+- [ ] a 🔼
+`)
+	tf, err := tui.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.SortBySection()
+	if err := tf.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# Work
+- [ ] (A) urgent
+- [ ] low !
+- [ ] dated sooner due:2026-10-20
+note stays here
+- [ ] dated later due:2026-11-01
+- [ ] plain
+
+## Home
+- [ ] a 🔼
+- [ ] b
+`
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("sorted file =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestSortBySection_KeepsSubtasksWithParents(t *testing.T) {
+	path := writeTempFile(t, `# Work
+- [ ] plain parent
+  - [ ] (A) urgent child
+  - [ ] child two
+    notes under child two
+- [ ] (B) medium
+- [ ] (A) high parent
+	- [x] tab child
+
+- [ ] dated due:2026-10-20
+`)
+	tf, err := tui.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.SortBySection()
+	if err := tf.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# Work
+- [ ] (A) high parent
+	- [x] tab child
+- [ ] (B) medium
+- [ ] dated due:2026-10-20
+
+- [ ] plain parent
+  - [ ] (A) urgent child
+  - [ ] child two
+    notes under child two
+`
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("sorted file =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestWithPriority_KeepsTodoLink(t *testing.T) {
+	item := tui.TodoItem{Text: "todo:work/tasks.md"}.WithPriority(tui.PriorityHigh)
+	if item.Text != "(A) todo:work/tasks.md" {
+		t.Fatalf("WithPriority = %q", item.Text)
+	}
+	if !item.IsLinkedTodo() || item.LinkedPath() != "work/tasks.md" {
+		t.Errorf("raised todo lost its link: linked %v, path %q", item.IsLinkedTodo(), item.LinkedPath())
+	}
+
+	dir := writeTodoTree(t, map[string]string{"work/tasks.md": "- [ ] one\n"})
+	from := filepath.Join(dir, "root.md")
+	if got := tui.NewLinkResolver(dir).Resolve(from, item); got != filepath.Join(dir, "work/tasks.md") {
+		t.Errorf("Resolve = %q, want work/tasks.md under %s", got, dir)
+	}
+	if got := item.WithPriority(tui.PriorityNone).Text; got != "todo:work/tasks.md" {
+		t.Errorf("clearing the priority gave %q", got)
+	}
+
+	for _, text := range []string{"!! todo:work/tasks.md", "🔼 todo:work/tasks.md"} {
+		raised := tui.TodoItem{Text: text}.WithPriority(tui.PriorityHigh)
+		if raised.Priority() != tui.PriorityHigh || raised.LinkedPath() != "work/tasks.md" {
+			t.Errorf("raising %q gave %q, linked path %q", text, raised.Text, raised.LinkedPath())
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestTagsAndDueDate(t *testing.T) {
+	item := tui.TodoItem{Text: "Ship #work/release and #Urgent due:2026-03-04 not#tag"}
+	tags := item.Tags()
+	if len(tags) != 2 || tags[0] != "work/release" || tags[1] != "Urgent" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+	if !item.HasTag("#urgent") || item.HasTag("tag") {
+		t.Error("HasTag should match case-insensitively and ignore mid-word #")
+	}
+
+	due, ok := item.DueDate()
+	if !ok || due.Format("2006-01-02") != "2026-03-04" {
+		t.Errorf("expected due 2026-03-04, got %v, %v", due, ok)
+	}
+	if _, ok := (tui.TodoItem{Text: "due:someday"}).DueDate(); ok {
+		t.Error("expected no due date for an invalid token")
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, source := range []string{"status:maybe", "due-before:soon"} {
+		if _, err := tui.ParseQuery(source); err == nil {
+			t.Errorf("expected an error for %q", source)
+		}
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":       "# Home\n- [ ] todo:work/tasks.md\n- [x] Water plants #home due:2026-01-05\n## Errands\n- [ ] Buy milk #home\n",
+		"work/tasks.md": "# Sprint\n- [ ] Ship release #work due:2026-01-03\n- [ ] Write \"release notes\" due:2026-01-09\n",
+		"orphan.md":     "- [ ] Not reachable #home\n",
+	})
+	graph := tui.BuildLinkGraph(filepath.Join(dir, "root.md"), tui.NewLinkResolver(dir))
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"todo:work/tasks.md", "Water plants #home due:2026-01-05", "Buy milk #home", "Ship release #work due:2026-01-03", "Write \"release notes\" due:2026-01-09"}},
+		{"status:open tag:home", []string{"Buy milk #home"}},
+		{"status:done", []string{"Water plants #home due:2026-01-05"}},
+		{"RELEASE", []string{"Ship release #work due:2026-01-03", "Write \"release notes\" due:2026-01-09"}},
+		{`"release notes"`, []string{"Write \"release notes\" due:2026-01-09"}},
+		{"due-before:2026-01-05", []string{"Ship release #work due:2026-01-03"}},
+		{"file:work/*.md", []string{"Ship release #work due:2026-01-03", "Write \"release notes\" due:2026-01-09"}},
+		{"file:root.md section:errands", []string{"Buy milk #home"}},
+		{"tag:nothing", nil},
+	}
+	for _, tt := range tests {
+		query, err := tui.ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+		}
+		results := tui.RunQuery(graph, dir, query)
+		var got []string
+		for _, result := range results {
+			got = append(got, result.Item.Text)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("query %q = %q, want %q", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("query %q = %q, want %q", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+
+	query, _ := tui.ParseQuery("section:sprint")
+	results := tui.RunQuery(graph, dir, query)
+	if len(results) != 2 || results[0].Path != filepath.Join(dir, "work", "tasks.md") || results[0].Line != 2 || results[0].TodoIndex != 0 {
+		t.Errorf("unexpected result location: %+v", results)
+	}
+}
+
+func TestAgenda(t *testing.T) {
+	results := []tui.QueryResult{
+		{Item: tui.TodoItem{Text: "Later due:2026-02-01"}},
+		{Item: tui.TodoItem{Text: "No date"}},
+		{Item: tui.TodoItem{Text: "Soon due:2026-01-01"}},
+		{Item: tui.TodoItem{Text: "Also later due:2026-02-01"}},
+	}
+	agenda := tui.Agenda(results)
+	want := []string{"Soon due:2026-01-01", "Later due:2026-02-01", "Also later due:2026-02-01"}
+	if len(agenda) != len(want) {
+		t.Fatalf("got %d agenda items, want %d", len(agenda), len(want))
+	}
+	for i := range want {
+		if agenda[i].Item.Text != want[i] {
+			t.Errorf("agenda[%d] = %q, want %q", i, agenda[i].Item.Text, want[i])
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestRecurrence(t *testing.T) {
+	tests := map[string]string{
+		"Trash every:week":              "week",
+		"Trash every:2W due:2026-10-20": "2w",
+		"Plants 🔁 every monday":         "monday",
+		"Plants 🔁 every 2 Weeks":        "2 weeks",
+		"Rent 🔁 every month":            "month",
+		"Trash every:fortnight":         "",
+		"Plants 🔁 every 0 days":         "",
+		"Read everything":               "",
+	}
+	for text, want := range tests {
+		if got := (tui.TodoItem{Text: text}).Recurrence(); got != want {
+			t.Errorf("Recurrence(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	// Sunday.
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Trash every:week due:2026-10-20", "Trash every:week due:2026-10-27"},
+		{"Water every:3d", "Water every:3d due:2026-10-21"},
+		{"Rent every:monthly due:2026-01-31", "Rent every:monthly due:2026-02-28"},
+		{"Plants 🔁 every monday 📅 2026-10-19", "Plants 🔁 every monday 📅 2026-10-26"},
+		{"Plants 🔁 every friday", "Plants 🔁 every friday 📅 2026-10-23"},
+		{"Taxes 🔁 every year 📅 2026-04-15", "Taxes 🔁 every year 📅 2027-04-15"},
+	}
+	for _, tt := range tests {
+		next, ok := tui.TodoItem{Text: tt.text, Checked: true}.NextOccurrence(now)
+		if !ok || next.Text != tt.want || next.Checked {
+			t.Errorf("NextOccurrence(%q) = %+v, %v; want unchecked %q", tt.text, next, ok, tt.want)
+		}
+	}
+
+	if _, ok := (tui.TodoItem{Text: "Once due:2026-10-20"}).NextOccurrence(now); ok {
+		t.Error("expected no next occurrence for a todo without a repeat rule")
+	}
+}
+
+func TestToggleTodo_Recurring(t *testing.T) {
+	content := "# Chores\n- [ ] Trash every:week due:2026-10-20\n- [ ] Dishes\n"
+	stamp := " ✅ " + time.Now().Format("2006-01-02")
+	tests := []struct {
+		name    string
+		options tui.FileOptions
+		want    string
+		nextIdx int
+	}{
+		{"after", tui.FileOptions{}, "# Chores\n- [x] Trash every:week due:2026-10-20" + stamp + "\n- [ ] Trash every:week due:2026-10-27\n- [ ] Dishes\n", 1},
+		{"in place", tui.FileOptions{RecurInPlace: true}, "# Chores\n- [ ] Trash every:week due:2026-10-27\n- [x] Trash every:week due:2026-10-20" + stamp + "\n- [ ] Dishes\n", 0},
+	}
+	for _, tt := range tests {
+		path := writeTempFile(t, content)
+		tf, err := tui.ParseFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tf.Options = tt.options
+
+		if nextIdx := tf.ToggleTodo(0); nextIdx != tt.nextIdx {
+			t.Errorf("%s: ToggleTodo returned %d, want %d", tt.name, nextIdx, tt.nextIdx)
+		}
+		if err := tf.Save(); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(path)
+		if string(data) != tt.want {
+			t.Errorf("%s: file =\n%s\nwant\n%s", tt.name, data, tt.want)
+		}
+	}
+}
+
+func TestToggleTodo_RecheckRecurringKeepsOneCopy(t *testing.T) {
+	for _, options := range []tui.FileOptions{{}, {RecurInPlace: true, CreatedDates: tui.DateMarkerToken}} {
+		tf, err := tui.ParseFile(writeTempFile(t, "- [ ] Trash every:week due:2026-10-20\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tf.Options = options
+
+		original := 1 - tf.ToggleTodo(0)
+		tf.ToggleTodo(original)
+		if nextIdx := tf.ToggleTodo(original); nextIdx != -1 {
+			t.Errorf("%+v: rechecking inserted another copy at %d", options, nextIdx)
+		}
+		if tf.TodoCount() != 2 {
+			t.Errorf("%+v: got %d todos, want the original and one copy:\n%s", options, tf.TodoCount(), strings.Join(tf.RawLines, "\n"))
+		}
+		if _, ok := tf.GetTodo(original).CompletedAt(); !ok {
+			t.Errorf("%+v: rechecked todo has no completion date: %q", options, tf.GetTodo(original).Text)
+		}
+	}
+}
+
+func TestToggleTodo_UncheckRecurringAddsNothing(t *testing.T) {
+	tf, err := tui.ParseFile(writeTempFile(t, "- [x] Trash every:week due:2026-10-20\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextIdx := tf.ToggleTodo(0); nextIdx != -1 || tf.TodoCount() != 1 {
+		t.Errorf("unchecking added a copy: index %d, %d todos", nextIdx, tf.TodoCount())
+	}
+	if got := strings.Join(tf.RawLines, "\n"); got != "- [ ] Trash every:week due:2026-10-20\n" {
+		t.Errorf("unexpected file: %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestFileProgress_Recursive(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":           "- [x] Done here\n- [ ] todo:work/tasks.md\n- [ ] Open here\n",
+		"work/tasks.md":     "- [x] One\n- [ ] Two\n- [x] todo:sprint/12.md\n",
+		"work/sprint/12.md": "- [x] A\n- [x] B\n- [ ] C\n",
+	})
+
+	progress, err := tui.FileProgress(filepath.Join(dir, "root.md"), nil, tui.ProgressCache{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// root: 2 plain items (1 done) + tasks.md: 2 plain (1 done) + 12.md: 3 (2 done)
+	if progress.Done != 4 || progress.Total != 7 {
+		t.Errorf("expected 4/7, got %d/%d", progress.Done, progress.Total)
+	}
+}
+
+func TestFileProgress_CycleAndBrokenLinks(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"a.md":     "- [ ] todo:b.md\n- [x] todo:missing.md\n",
+		"b.md":     "- [x] Leaf\n- [ ] todo:a.md\n",
+		"empty.md": "# Nothing yet\n",
+	})
+
+	progress, err := tui.FileProgress(filepath.Join(dir, "a.md"), nil, tui.ProgressCache{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b.md counts Leaf plus its cyclic link as a plain item; missing.md counts as its own checkbox.
+	if progress.Done != 2 || progress.Total != 3 {
+		t.Errorf("expected 2/3, got %d/%d", progress.Done, progress.Total)
+	}
+}
+
+func TestLinkProgress(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"root.md":  "- [ ] todo:list.md\n- [ ] todo:empty.md\n",
+		"list.md":  "- [x] One\n- [x] Two\n",
+		"empty.md": "# Nothing yet\n",
+	})
+	rootPath := filepath.Join(dir, "root.md")
+	cache := tui.ProgressCache{}
+
+	progress, ok := tui.LinkProgress(rootPath, tui.TodoItem{Text: "todo:list.md"}, nil, cache)
+	if !ok || !progress.Complete() {
+		t.Errorf("expected complete progress for list.md, got %+v (ok=%v)", progress, ok)
+	}
+	if _, ok := tui.LinkProgress(rootPath, tui.TodoItem{Text: "todo:empty.md"}, nil, cache); ok {
+		t.Error("expected no progress for a linked file without todos")
+	}
+	if _, ok := tui.LinkProgress(rootPath, tui.TodoItem{Text: "Plain item"}, nil, cache); ok {
+		t.Error("expected no progress for a plain item")
+	}
+}
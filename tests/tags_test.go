@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestLabels(t *testing.T) {
+	item := tui.TodoItem{Text: "Fix #bug for +projectX @home, mail a@b.com +1 @2pm #42"}
+	if got, want := item.Labels(), []string{"#bug", "+projectX", "@home", "#42"}; !slices.Equal(got, want) {
+		t.Errorf("Labels() = %q, want %q", got, want)
+	}
+	if got := item.Tags(); !slices.Equal(got, []string{"bug", "42"}) {
+		t.Errorf("Tags() = %q", got)
+	}
+	if got := item.Contexts(); !slices.Equal(got, []string{"home"}) {
+		t.Errorf("Contexts() = %q", got)
+	}
+	if got := item.Projects(); !slices.Equal(got, []string{"projectX"}) {
+		t.Errorf("Projects() = %q", got)
+	}
+}
+
+func TestHasTag_Sigils(t *testing.T) {
+	item := tui.TodoItem{Text: "Call #Work @home +garden"}
+	for _, tag := range []string{"work", "#work", "@HOME", "+garden"} {
+		if !item.HasTag(tag) {
+			t.Errorf("expected HasTag(%q)", tag)
+		}
+	}
+	for _, tag := range []string{"home", "#home", "@work", "garden"} {
+		if item.HasTag(tag) {
+			t.Errorf("expected !HasTag(%q)", tag)
+		}
+	}
+}
+
+func TestCountLabels(t *testing.T) {
+	items := []tui.TodoItem{
+		{Text: "a #bug @home"},
+		{Text: "b #Bug #bug"},
+		{Text: "c +proj @home"},
+		{Text: "d #bug"},
+		{Text: "e"},
+	}
+	want := []tui.LabelCount{
+		{Label: "#bug", Count: 3},
+		{Label: "@home", Count: 2},
+		{Label: "+proj", Count: 1},
+	}
+	if got := tui.CountLabels(items); !slices.Equal(got, want) {
+		t.Errorf("CountLabels() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLabels_OnLinkedTodo(t *testing.T) {
+	item := tui.TodoItem{Text: "todo:work.md #work @office"}
+	if got := item.LinkedPath(); got != "work.md" {
+		t.Errorf("LinkedPath() = %q, want work.md", got)
+	}
+	if !item.HasTag("work") || !item.HasTag("@office") {
+		t.Errorf("labels on a linked todo were not found: %v", item.Labels())
+	}
+}
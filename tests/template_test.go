@@ -0,0 +1,23 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestRenderFileTemplate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	got := tui.RenderFileTemplate(tui.DefaultFileTemplate, "sprint-13", now)
+	expected := "---\ncreated: 2026-10-18\n---\n\n# sprint-13\n"
+	if got != expected {
+		t.Errorf("default template rendered %q, want %q", got, expected)
+	}
+
+	custom := tui.RenderFileTemplate("# {{title}} ({{date}})\n\n- [ ] Plan {{title}}\n", "Q4", now)
+	if custom != "# Q4 (2026-10-18)\n\n- [ ] Plan Q4\n" {
+		t.Errorf("custom template rendered %q", custom)
+	}
+}
@@ -365,3 +365,47 @@ func TestSave_PreservesNonTodoLines(t *testing.T) {
 		t.Error("sub-heading was lost")
 	}
 }
+
+func TestInsertTodoBefore(t *testing.T) {
+	path := writeTempFile(t, testMarkdown)
+	tf, _ := tui.ParseFile(path)
+
+	tf.InsertTodoBefore(1, tui.TodoItem{Text: "Pasted task", Checked: true})
+
+	if tf.TodoCount() != 5 {
+		t.Errorf("expected 5 todos after insert, got %d", tf.TodoCount())
+	}
+	item := tf.GetTodo(1)
+	if item.Text != "Pasted task" || !item.Checked {
+		t.Errorf("expected checked 'Pasted task' at todo[1], got %+v", item)
+	}
+	if next := tf.GetTodo(2); next.Text != "Buy groceries" {
+		t.Errorf("expected 'Buy groceries' to shift to todo[2], got %q", next.Text)
+	}
+}
+
+func TestInsertTodoBefore_EmptyFile(t *testing.T) {
+	path := writeTempFile(t, "# My List\n")
+	tf, _ := tui.ParseFile(path)
+
+	tf.InsertTodoBefore(0, tui.TodoItem{Text: "First task"})
+
+	if tf.TodoCount() != 1 {
+		t.Fatalf("expected 1 todo, got %d", tf.TodoCount())
+	}
+	if item := tf.GetTodo(0); item.Text != "First task" {
+		t.Errorf("expected 'First task', got %q", item.Text)
+	}
+}
+
+func TestSection(t *testing.T) {
+	path := writeTempFile(t, "- [ ] Before any heading\n# Work\n\n- [ ] Ship it\n## Later ##\ntext\n- [x] Someday\n#notaheading\n- [ ] Still later\n")
+	tf, _ := tui.ParseFile(path)
+
+	want := []string{"", "Work", "Later", "Later"}
+	for i, section := range want {
+		if got := tf.Section(i); got != section {
+			t.Errorf("Section(%d) = %q, want %q", i, got, section)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Jevs21/jeb-todo-md/internal/tui"
+)
+
+func TestListMarkdownFiles(t *testing.T) {
+	dir := writeTodoTree(t, map[string]string{
+		"todo.md":                "",
+		"work/tasks.md":          "",
+		"work/notes.markdown":    "",
+		"work/image.png":         "",
+		".obsidian/workspace.md": "",
+	})
+
+	files, err := tui.ListMarkdownFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"todo.md", "work/notes.markdown", "work/tasks.md"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("ListMarkdownFiles = %v, want %v", files, expected)
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	candidates := []string{"personal/groceries.md", "work/sprint-12.md", "work/standup.md", "todo.md"}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"", candidates},
+		{"sprint", []string{"work/sprint-12.md"}},
+		{"WSP", []string{"work/sprint-12.md", "work/standup.md"}},
+		{"stup", []string{"work/standup.md"}},
+		{"zzz", []string{}},
+	}
+
+	for _, tt := range tests {
+		got := tui.FuzzyMatch(tt.query, candidates)
+		if len(got) == 0 && len(tt.expected) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("FuzzyMatch(%q) = %v, want %v", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestFuzzyMatch_PrefersFileName(t *testing.T) {
+	candidates := []string{"todo/archive.md", "work/todo.md"}
+	got := tui.FuzzyMatch("todo", candidates)
+	if len(got) != 2 || got[0] != "work/todo.md" {
+		t.Errorf("expected file name match first, got %v", got)
+	}
+}